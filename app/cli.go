@@ -1,8 +1,12 @@
 package app
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
+	"crudify/engine"
+	"crudify/packs"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -23,6 +27,8 @@ func NewCliApp() *cli.App {
 		Description: "Template based CRUD code generator",
 		Commands: []*cli.Command{
 			NewGenerateCommand(),
+			NewTypesCommand(),
+			NewInitCommand(),
 		},
 	}
 	return app
@@ -36,9 +42,12 @@ func NewGenerateCommand() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "debug", Required: false, Value: false},
 			&cli.BoolFlag{Name: "trace", Required: false, Value: false},
-			&cli.StringFlag{Name: "template", Aliases: []string{"t"}, Required: false, Value: AppName + ".template"},
+			&cli.StringFlag{Name: "template", Aliases: []string{"t"}, Required: false, Value: AppName + ".template", Usage: fmt.Sprintf("template directory, .zip or .tar.gz archive, git repository with an optional #ref, or builtin pack: %s", builtinPackNames())},
 			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Required: false, Value: AppName + ".output"},
 			&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Required: false, Value: AppName + ".config.yaml"},
+			&cli.BoolFlag{Name: "watch", Aliases: []string{"w"}, Required: false, Value: false, Usage: "re-render templates when the template directory or config file changes"},
+			&cli.BoolFlag{Name: "keep-going", Aliases: []string{"k"}, Required: false, Value: false, Usage: "continue after failed renderings and report all failures at the end"},
+			&cli.IntFlag{Name: "jobs", Aliases: []string{"j"}, Required: false, Value: 0, Usage: "number of parallel render workers, defaults to the number of CPUs"},
 		},
 		Action: func(ctx *cli.Context) error {
 			debug := ctx.Bool("debug")
@@ -49,10 +58,55 @@ func NewGenerateCommand() *cli.Command {
 			if trace {
 				logrus.SetLevel(logrus.TraceLevel)
 			}
-			tmplDir := ctx.String("template")
-			outputDir := ctx.String("output")
-			configFile := ctx.String("config")
-			return ExecGenerate(tmplDir, outputDir, configFile)
+			opts := &engine.GeneratorOptions{
+				TemplateDir: ctx.String("template"),
+				OutputDir:   ctx.String("output"),
+				ConfigFile:  ctx.String("config"),
+				Jobs:        ctx.Int("jobs"),
+				KeepGoing:   ctx.Bool("keep-going"),
+			}
+			if ctx.Bool("watch") {
+				return ExecWatch(opts)
+			}
+			return ExecGenerate(opts)
+		},
+	}
+}
+
+func NewTypesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "types",
+		Usage: "Generate type declarations of the script API",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "lang", Required: false, Value: engine.TypeScriptLang, Usage: "declaration language, only ts is supported"},
+			&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Required: false, Value: AppName + ".d.ts", Usage: `output file, "-" for stdout`},
+		},
+		Action: func(ctx *cli.Context) error {
+			return ExecTypes(ctx.String("lang"), ctx.String("output"))
+		},
+	}
+}
+
+func NewInitCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "init",
+		Usage: "Create a starter template pack and config file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "lang", Required: false, Value: "go", Usage: fmt.Sprintf("language of the starter pack: %s", strings.Join(packs.StarterLanguages(), ", "))},
+			&cli.StringFlag{Name: "template", Aliases: []string{"t"}, Required: false, Value: AppName + ".template"},
+			&cli.StringFlag{Name: "config", Aliases: []string{"c"}, Required: false, Value: AppName + ".config.yaml"},
+			&cli.BoolFlag{Name: "force", Aliases: []string{"f"}, Required: false, Value: false, Usage: "overwrite existing files"},
+		},
+		Action: func(ctx *cli.Context) error {
+			return ExecInit(ctx.String("lang"), ctx.String("template"), ctx.String("config"), ctx.Bool("force"))
 		},
 	}
 }
+
+func builtinPackNames() string {
+	names := []string{}
+	for _, name := range packs.BuiltinNames() {
+		names = append(names, engine.BuiltinPackPrefix+name)
+	}
+	return strings.Join(names, ", ")
+}
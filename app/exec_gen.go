@@ -5,13 +5,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func ExecGenerate(tmplDir, outputDir, configFile string) error {
+func ExecGenerate(opts *engine.GeneratorOptions) error {
 	logrus.Info("Generation started")
-	logrus.Infof("Template directory: %s", tmplDir)
-	logrus.Infof("Output directory: %s", outputDir)
-	logrus.Infof("Config file: %s", configFile)
+	logrus.Infof("Template directory: %s", opts.TemplateDir)
+	logrus.Infof("Output directory: %s", opts.OutputDir)
+	logrus.Infof("Config file: %s", opts.ConfigFile)
 
-	generator, err := engine.NewGenerator(tmplDir, outputDir, configFile)
+	generator, err := engine.NewGenerator(opts)
 	if err != nil {
 		return err
 	}
@@ -24,3 +24,17 @@ func ExecGenerate(tmplDir, outputDir, configFile string) error {
 	logrus.Info("Generation finished")
 	return nil
 }
+
+func ExecWatch(opts *engine.GeneratorOptions) error {
+	logrus.Info("Watch started")
+	logrus.Infof("Template directory: %s", opts.TemplateDir)
+	logrus.Infof("Output directory: %s", opts.OutputDir)
+	logrus.Infof("Config file: %s", opts.ConfigFile)
+
+	generator, err := engine.NewGenerator(opts)
+	if err != nil {
+		return err
+	}
+
+	return generator.Watch()
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"crudify/packs"
+	"github.com/sirupsen/logrus"
+)
+
+// ExecInit writes the starter pack of the language into the template directory
+// and a sample config file. Existing files are kept unless force is set.
+func ExecInit(lang string, tmplDir string, configFile string, force bool) error {
+	pack, err := packs.Starter(lang)
+	if err != nil {
+		return err
+	}
+
+	err = fs.WalkDir(pack, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(pack, name)
+		if err != nil {
+			return err
+		}
+		return writeInitFile(filepath.Join(tmplDir, filepath.FromSlash(name)), content, force)
+	})
+	if err != nil {
+		return err
+	}
+
+	config, err := packs.StarterConfig()
+	if err != nil {
+		return err
+	}
+	err = writeInitFile(configFile, config, force)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Initialized %s template pack: %s, config: %s", lang, tmplDir, configFile)
+	return nil
+}
+
+func writeInitFile(filename string, content []byte, force bool) error {
+	if !force {
+		_, err := os.Stat(filename)
+		if err == nil {
+			logrus.Warnf("File exists, skipped: %s", filename)
+			return nil
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(filename), 0755)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filename, content, 0644)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("File written: %s", filename)
+	return nil
+}
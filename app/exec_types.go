@@ -0,0 +1,30 @@
+package app
+
+import (
+	"os"
+
+	"crudify/engine"
+	"github.com/sirupsen/logrus"
+)
+
+func ExecTypes(lang string, output string) error {
+	if output == "-" {
+		return engine.WriteTypes(os.Stdout, lang)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	err = engine.WriteTypes(file, lang)
+	if e := file.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Types written: %s", output)
+	return nil
+}
@@ -2,6 +2,9 @@ package engine
 
 import (
 	"os"
+	"time"
+
+	"crudify/schema/common"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,9 +17,21 @@ type DatabaseProps struct {
 	Database string `yaml:"database"`
 }
 
+// ScriptProps limits the scripts of a template pack. A zero timeout uses the
+// default timeout, a negative one disables it. A zero call stack size keeps
+// the unlimited default of goja.
+type ScriptProps struct {
+	Timeout          time.Duration `yaml:"timeout"`
+	MaxCallStackSize int           `yaml:"max-call-stack-size"`
+}
+
 type ConfigModel struct {
-	Database  DatabaseProps  `yaml:"database"`
-	Variables map[string]any `yaml:"variables"`
+	Database    DatabaseProps            `yaml:"database"`
+	Variables   map[string]any           `yaml:"variables"`
+	Scripts     ScriptProps              `yaml:"scripts"`
+	Types       common.TypeOptions       `yaml:"types"`
+	Identifiers common.IdentifierOptions `yaml:"identifiers"`
+	Naming      common.NamingOptions     `yaml:"naming"`
 }
 
 func ReadConfig(filename string) (*ConfigModel, error) {
@@ -14,3 +14,19 @@ type EntityTemplateData struct {
 	Vars   map[string]any
 	Table  *common.TableSchema
 }
+
+// HookContext is passed to the beforeAll hook. Variables set on Vars are
+// visible to all templates rendered afterwards. Tables is the table list when
+// the hook is called, Schema.tables() returns the list changed by the hook.
+type HookContext struct {
+	Vars   map[string]any
+	Tables []*common.TableSchema
+}
+
+// HookReport is passed to the afterAll hook, Files are the written files
+// relative to the output directory.
+type HookReport struct {
+	Files  []string
+	Errors []string
+	Tables []*common.TableSchema
+}
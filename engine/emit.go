@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+)
+
+// emitRequest is an extra output file requested by a script, rendered from
+// Template with Data once all manifest templates are rendered.
+type emitRequest struct {
+	Path     string
+	Template string
+	Data     any
+}
+
+// renderEmitted renders the output files requested by scripts, in the order
+// of the renders that requested them.
+func (g *Generator) renderEmitted(ctx *genContext) error {
+	if len(ctx.Emits) <= 0 {
+		return nil
+	}
+
+	logrus.Infof("Rendering emitted files: %d", len(ctx.Emits))
+
+	templates := map[string]*template.Template{}
+
+	for _, emit := range ctx.Emits {
+		props := &TemplateProps{File: emit.Template, Output: emit.Path}
+		err := g.renderEmit(ctx, templates, props, emit)
+		if err != nil {
+			err = g.fail(ctx, newRenderError(props, "", emit.Path, err))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Generator) renderEmit(ctx *genContext, templates map[string]*template.Template,
+	props *TemplateProps, emit *emitRequest) error {
+
+	logrus.Debugf("Rendering emitted file: %s, %s", emit.Template, emit.Path)
+
+	tmpl, ok := templates[emit.Template]
+	if !ok {
+		var err error
+		tmpl, err = g.parseTemplate(ctx, props)
+		if err != nil {
+			return err
+		}
+		templates[emit.Template] = tmpl
+	}
+
+	content, err := renderTemplate(tmpl, emit.Data)
+	if err != nil {
+		return err
+	}
+
+	content, err = formatOutput(ctx.Manifest, props, emit.Path, content)
+	if err != nil {
+		return err
+	}
+
+	return g.writeFile(ctx, props, emit.Path, content)
+}
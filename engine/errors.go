@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// RenderError is a failure while rendering a template, optionally for a table.
+// Line and Column locate the error in the template file if known.
+type RenderError struct {
+	Template string
+	Line     int
+	Column   int
+	Table    string
+	Output   string
+	Err      error
+}
+
+func newRenderError(props *TemplateProps, table string, output string, err error) *RenderError {
+	var renderErr *RenderError
+	if errors.As(err, &renderErr) {
+		return renderErr
+	}
+
+	e := &RenderError{
+		Template: props.File,
+		Table:    table,
+		Output:   output,
+		Err:      err,
+	}
+	e.Line, e.Column = templateErrorPosition(props.File, err)
+	return e
+}
+
+func (e *RenderError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Template)
+	if e.Line > 0 {
+		sb.WriteString(":" + strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			sb.WriteString(":" + strconv.Itoa(e.Column))
+		}
+	}
+
+	details := []string{}
+	if e.Table != "" {
+		details = append(details, "table: "+e.Table)
+	}
+	if e.Output != "" {
+		details = append(details, "output: "+e.Output)
+	}
+	if len(details) > 0 {
+		sb.WriteString(" (" + strings.Join(details, ", ") + ")")
+	}
+
+	// The location is already part of the message above.
+	msg := e.Err.Error()
+	if e.Line > 0 {
+		msg = strings.TrimPrefix(msg, fmt.Sprintf("template: %s:%d:%d: ", e.Template, e.Line, e.Column))
+		msg = strings.TrimPrefix(msg, fmt.Sprintf("template: %s:%d: ", e.Template, e.Line))
+	}
+
+	sb.WriteString(": " + msg)
+	return sb.String()
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// ScriptError is a failure of a script, located by script file, line and column.
+type ScriptError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ScriptError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("script %s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("script %s: %s", e.File, e.Message)
+}
+
+// GenerateError is the aggregated report of all failures of a keep-going run.
+type GenerateError struct {
+	Errors []error
+}
+
+func (e *GenerateError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d rendering(s) failed:", len(e.Errors)))
+	for i, err := range e.Errors {
+		sb.WriteString(fmt.Sprintf("\n  %d) %s", i+1, err.Error()))
+	}
+	return sb.String()
+}
+
+func (e *GenerateError) Unwrap() []error {
+	return e.Errors
+}
+
+// templateErrorPosition extracts the line and column from the errors of
+// text/template, which are formatted as "template: name:line:column: ...".
+func templateErrorPosition(name string, err error) (int, int) {
+	var execErr template.ExecError
+	if !errors.As(err, &execErr) && !strings.HasPrefix(err.Error(), "template: ") {
+		return 0, 0
+	}
+
+	msg := strings.TrimPrefix(err.Error(), "template: "+name+":")
+	parts := strings.SplitN(msg, ":", 3)
+	line, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0
+	}
+	column := 0
+	if len(parts) > 2 {
+		column, _ = strconv.Atoi(parts[1])
+	}
+	return line, column
+}
@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"crudify/utils"
+)
+
+// loadManifest reads the manifest of the pack merged with the manifests of the
+// packs it extends, which are returned nearest first. Seen holds the sources
+// of the extending packs to detect cycles.
+func loadManifest(pack *templatePack, seen []string) (*ManifestModel, []*templatePack, error) {
+	manifest, err := ReadManifest(pack.FS)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest.Extends == "" {
+		manifest.GlobalTemplates = enabledTemplates(manifest.GlobalTemplates)
+		manifest.EntityTemplates = enabledTemplates(manifest.EntityTemplates)
+		return manifest, nil, nil
+	}
+
+	source := resolveExtends(pack, manifest.Extends)
+	seen = append(seen, packKey(pack.Info.Source, pack.Info.Ref))
+	repo, ref, _ := strings.Cut(source, "#")
+	if key := packKey(repo, ref); slices.Contains(seen, key) {
+		return nil, nil, fmt.Errorf("cyclic extends: %s -> %s", strings.Join(seen, " -> "), key)
+	}
+
+	base, err := openTemplatePack(source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extends %s: %w", manifest.Extends, err)
+	}
+
+	baseManifest, bases, err := loadManifest(base, seen)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extends %s: %w", manifest.Extends, err)
+	}
+
+	return mergeManifest(baseManifest, manifest), append([]*templatePack{base}, bases...), nil
+}
+
+// resolveExtends returns the template source of the extends of the pack's
+// manifest, with a relative path resolved against the base of the pack.
+func resolveExtends(pack *templatePack, extends string) string {
+	if strings.HasPrefix(extends, BuiltinPackPrefix) || filepath.IsAbs(extends) {
+		return extends
+	}
+	return filepath.Join(pack.Base, extends)
+}
+
+// packKey identifies a template source by its cleaned path and ref.
+func packKey(source, ref string) string {
+	if !strings.HasPrefix(source, BuiltinPackPrefix) {
+		source = filepath.Clean(source)
+	}
+	if ref != "" {
+		return source + "#" + ref
+	}
+	return source
+}
+
+// mergeManifest returns the manifest extending the base manifest. Lists are
+// appended to the lists of the base, templates override the inherited
+// templates and the other settings of the manifest take precedence.
+func mergeManifest(base, m *ManifestModel) *ManifestModel {
+	merged := *m
+	merged.Variables = utils.MergeVariables(base.Variables, m.Variables)
+	merged.GlobalScripts = appendUnique(base.GlobalScripts, m.GlobalScripts)
+	merged.EntityScripts = appendUnique(base.EntityScripts, m.EntityScripts)
+	merged.GlobalTemplates = mergeTemplates(base.GlobalTemplates, m.GlobalTemplates)
+	merged.EntityTemplates = mergeTemplates(base.EntityTemplates, m.EntityTemplates)
+	merged.PostProcess = slices.Concat(base.PostProcess, m.PostProcess)
+	merged.Static = slices.Concat(base.Static, m.Static)
+	merged.Directories = slices.Concat(base.Directories, m.Directories)
+	if merged.Hooks == "" {
+		merged.Hooks = base.Hooks
+	}
+	merged.TemplateFunctions = appendUnique(base.TemplateFunctions, m.TemplateFunctions)
+	merged.Types = base.Types.Merge(m.Types)
+	merged.Identifiers = base.Identifiers.Merge(m.Identifiers)
+	merged.Naming = base.Naming.Merge(m.Naming)
+	return &merged
+}
+
+// mergeTemplates overrides the inherited templates with the templates of the
+// same key, in place, and appends the other templates. Only the fields set by
+// the overriding template are changed. Disabled templates are removed.
+func mergeTemplates(base, templates []TemplateProps) []TemplateProps {
+	merged := slices.Clone(base)
+	for _, props := range templates {
+		i := slices.IndexFunc(merged, func(p TemplateProps) bool {
+			return templateKey(p) == templateKey(props)
+		})
+		if i < 0 {
+			merged = append(merged, props)
+			continue
+		}
+
+		inherited := &merged[i]
+		if props.File != "" {
+			inherited.File = props.File
+		}
+		if props.Script != "" {
+			inherited.Script = props.Script
+		}
+		if props.Output != "" {
+			inherited.Output = props.Output
+		}
+		if props.PostProcess != nil {
+			inherited.PostProcess = props.PostProcess
+		}
+		inherited.Disabled = props.Disabled
+	}
+	return enabledTemplates(merged)
+}
+
+func templateKey(props TemplateProps) string {
+	if props.ID != "" {
+		return "id:" + props.ID
+	}
+	return "file:" + path.Clean(props.File)
+}
+
+func enabledTemplates(templates []TemplateProps) []TemplateProps {
+	return slices.DeleteFunc(templates, func(p TemplateProps) bool {
+		return p.Disabled
+	})
+}
+
+func appendUnique(list, other []string) []string {
+	merged := slices.Clone(list)
+	for _, item := range other {
+		if !slices.Contains(merged, item) {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// packInfo returns the info of the template pack and the packs it extends.
+func (g *Generator) packInfo() PackInfo {
+	info := g.pack.Info
+	info.Extends = nil
+	for _, base := range g.bases {
+		info.Extends = append(info.Extends, base.Info)
+	}
+	return info
+}
+
+// layerFS overlays the files of template packs. The files of a pack hide the
+// files with the same name of the packs it extends, directories list the files
+// of all packs.
+type layerFS []fs.FS
+
+func newLayerFS(pack *templatePack, bases []*templatePack) fs.FS {
+	if len(bases) == 0 {
+		return pack.FS
+	}
+	layers := layerFS{pack.FS}
+	for _, base := range bases {
+		layers = append(layers, base.FS)
+	}
+	return layers
+}
+
+func (l layerFS) Open(name string) (fs.File, error) {
+	var firstErr error
+	for _, layer := range l {
+		file, err := layer.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+func (l layerFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := []fs.DirEntry{}
+	found := false
+	for _, layer := range l {
+		list, err := fs.ReadDir(layer, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range list {
+			if !slices.ContainsFunc(entries, func(e fs.DirEntry) bool { return e.Name() == entry.Name() }) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
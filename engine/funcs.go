@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"crudify/schema/common"
+	"crudify/utils"
+	"github.com/dop251/goja"
+)
+
+var reFuncName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// builtinFuncs are the template functions of every template, as in
+// {{kotlinType .}} within a range over the columns or {{pluralTitleCase .Vars.Name}}.
+var builtinFuncs = template.FuncMap{
+	"csharpType":                 (*common.ColumnSchema).CSharpDataType,
+	"javaType":                   (*common.ColumnSchema).JavaDataType,
+	"goType":                     (*common.ColumnSchema).GoDataType,
+	"pythonType":                 (*common.ColumnSchema).PythonDataType,
+	"typescriptType":             (*common.ColumnSchema).TypeScriptDataType,
+	"kotlinType":                 (*common.ColumnSchema).KotlinDataType,
+	"rustType":                   (*common.ColumnSchema).RustDataType,
+	"dartType":                   (*common.ColumnSchema).DartDataType,
+	"swiftType":                  (*common.ColumnSchema).SwiftDataType,
+	"phpType":                    (*common.ColumnSchema).PHPDataType,
+	"typeFor":                    typeFor,
+	"safeIdent":                  common.SafeIdentifier,
+	"isKeyword":                  common.IsKeyword,
+	"camelInitialisms":           utils.ToCamelCaseInitialisms,
+	"pascalInitialisms":          utils.ToPascalCaseInitialisms,
+	"camelCase":                  utils.ToCamelCase,
+	"pascalCase":                 utils.ToPascalCase,
+	"snakeCase":                  utils.ToSnakeCase,
+	"kebabCase":                  utils.ToKebabCase,
+	"screamingSnakeCase":         utils.ToScreamingSnakeCase,
+	"dotCase":                    utils.ToDotCase,
+	"titleCase":                  utils.ToTitleCase,
+	"pathCase":                   utils.ToPathCase,
+	"flatCase":                   utils.ToFlatCase,
+	"pluralCamelCase":            utils.ToPluralCamelCase,
+	"pluralPascalCase":           utils.ToPluralPascalCase,
+	"pluralSnakeCase":            utils.ToPluralSnakeCase,
+	"pluralKebabCase":            utils.ToPluralKebabCase,
+	"pluralScreamingSnakeCase":   utils.ToPluralScreamingSnakeCase,
+	"pluralDotCase":              utils.ToPluralDotCase,
+	"pluralTitleCase":            utils.ToPluralTitleCase,
+	"pluralPathCase":             utils.ToPluralPathCase,
+	"pluralFlatCase":             utils.ToPluralFlatCase,
+	"singularCamelCase":          utils.ToSingularCamelCase,
+	"singularPascalCase":         utils.ToSingularPascalCase,
+	"singularSnakeCase":          utils.ToSingularSnakeCase,
+	"singularKebabCase":          utils.ToSingularKebabCase,
+	"singularScreamingSnakeCase": utils.ToSingularScreamingSnakeCase,
+	"singularDotCase":            utils.ToSingularDotCase,
+	"singularTitleCase":          utils.ToSingularTitleCase,
+	"singularPathCase":           utils.ToSingularPathCase,
+	"singularFlatCase":           utils.ToSingularFlatCase,
+}
+
+// typeFor returns the type of the column in the language, as in
+// {{typeFor "java" .}}.
+func typeFor(lang string, column *common.ColumnSchema) (string, error) {
+	t := column.TypeFor(lang)
+	if t == "" {
+		return "", fmt.Errorf("unknown language %s, expected one of %s", lang,
+			strings.Join(common.Languages(), ", "))
+	}
+	return t, nil
+}
+
+// templateFuncs returns the template functions of this context, the builtin
+// functions and the script functions. Every function exported by the
+// manifest's template function modules is registered under its own name and
+// can also be called by name with jsfn. The functions are bound to a runtime of
+// this context and must only be called from the goroutine that owns it.
+func (sc *scriptContext) templateFuncs(files []string) (template.FuncMap, error) {
+	if sc.funcs != nil {
+		return sc.funcs, nil
+	}
+
+	funcs := maps.Clone(builtinFuncs)
+	jsFuncs := map[string]func(...any) (any, error){}
+
+	if len(files) > 0 {
+		vm, err := sc.newRuntime()
+		if err != nil {
+			return nil, err
+		}
+
+		require, _ := goja.AssertFunction(vm.Get("require"))
+		for _, file := range files {
+			stop := sc.startTimeout(vm)
+			exports, err := require(goja.Undefined(), vm.ToValue("./"+file))
+			stop()
+			if err != nil {
+				return nil, sc.newScriptError(file, err)
+			}
+
+			obj := exports.ToObject(vm)
+			for _, name := range obj.Keys() {
+				fn, ok := goja.AssertFunction(obj.Get(name))
+				if !ok {
+					continue
+				}
+				jsFunc := sc.wrapJsFunc(vm, file, fn)
+				jsFuncs[name] = jsFunc
+				if reFuncName.MatchString(name) {
+					funcs[name] = jsFunc
+				}
+			}
+		}
+	}
+
+	funcs["jsfn"] = func(name string, args ...any) (any, error) {
+		fn, ok := jsFuncs[name]
+		if !ok {
+			return nil, fmt.Errorf("jsfn: unknown function: %s", name)
+		}
+		return fn(args...)
+	}
+
+	sc.funcs = funcs
+	return funcs, nil
+}
+
+// wrapJsFunc converts the arguments of a template function call to JS values
+// and the result back to a Go value. Go values such as *common.TableSchema are
+// passed as wrapped objects, undefined and null results become "".
+func (sc *scriptContext) wrapJsFunc(vm *goja.Runtime, file string, fn goja.Callable) func(...any) (any, error) {
+	return func(args ...any) (any, error) {
+		values := make([]goja.Value, len(args))
+		for i, arg := range args {
+			values[i] = vm.ToValue(arg)
+		}
+
+		stop := sc.startTimeout(vm)
+		result, err := fn(goja.Undefined(), values...)
+		stop()
+		if err != nil {
+			return nil, sc.newScriptError(file, err)
+		}
+		if goja.IsUndefined(result) || goja.IsNull(result) {
+			return "", nil
+		}
+		return result.Export(), nil
+	}
+}
+
+// bindFuncs returns a copy of the template that calls the functions of this
+// context, so that a worker never calls into another worker's runtime.
+func (sc *scriptContext) bindFuncs(tmpl *template.Template, files []string) (*template.Template, error) {
+	if tmpl == nil || len(files) <= 0 {
+		return tmpl, nil
+	}
+
+	if bound, ok := sc.bound[tmpl]; ok {
+		return bound, nil
+	}
+
+	funcs, err := sc.templateFuncs(files)
+	if err != nil {
+		return nil, err
+	}
+
+	bound, err := tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	bound.Funcs(funcs)
+
+	sc.bound[tmpl] = bound
+	return bound, nil
+}
@@ -1,47 +1,127 @@
 package engine
 
 import (
-	"bufio"
 	"bytes"
-	"log"
+	"io/fs"
 	"os"
 	"path"
-	"path/filepath"
-	"strings"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"text/template"
 	"time"
 
 	"crudify/schema/common"
 	"crudify/schema/mysql"
 	"crudify/utils"
-	"github.com/robertkrimen/otto"
 	"github.com/sirupsen/logrus"
 	"github.com/vbauerster/mpb/v8"
 	"github.com/vbauerster/mpb/v8/decor"
 )
 
+type GeneratorOptions struct {
+	// TemplateDir is a template directory, a builtin pack as in
+	// "builtin:go-sqlx", a zip or tar.gz archive or a git repository with an
+	// optional ref as in "./packs.git#v2.1".
+	TemplateDir string
+	OutputDir   string
+	ConfigFile  string
+	// Jobs is the number of table × template pairs rendered in parallel,
+	// zero or less means one worker per CPU.
+	Jobs int
+	// KeepGoing continues after failed renderings and reports all failures
+	// at the end instead of stopping at the first one.
+	KeepGoing bool
+}
+
 type Generator struct {
-	config    *ConfigModel
+	config     *ConfigModel
+	configFile string
+	// pack is the template pack and bases are the packs it extends, tmplFS
+	// holds the files of all of them. tmplDir is the host directory of the
+	// pack or empty if the pack is not a directory.
+	pack      *templatePack
+	bases     []*templatePack
+	tmplFS    fs.FS
 	tmplDir   string
 	outputDir string
+	jobs      int
+	keepGoing bool
 }
 
 type genContext struct {
 	Manifest *ManifestModel
 	Vars     map[string]any
+	// DbTables is the schema read from the database, Tables is the copy of it
+	// used by a render, which the hooks may change.
+	DbTables []*common.TableSchema
 	Tables   []*common.TableSchema
+	Files    []*writtenFile
+	Errors   []error
+	Hooks    *hookRunner
+	Emits    []*emitRequest
+	// Scripts is the script context of the generator's goroutine, Funcs are
+	// its template functions, which templates are parsed with.
+	Scripts *scriptContext
+	Funcs   template.FuncMap
+}
+
+// writtenFile is an output file written during the current render, Path is
+// relative to the output directory.
+type writtenFile struct {
+	Path     string
+	Template *TemplateProps
+}
+
+// templateFilter selects the templates to render, a nil filter selects all.
+type templateFilter func(props *TemplateProps) bool
+
+func (f templateFilter) accept(props *TemplateProps) bool {
+	return f == nil || f(props)
+}
+
+type entityTemplate struct {
+	props *TemplateProps
+	tmpl  *template.Template
+	bar   *mpb.Bar
+}
+
+type entityJob struct {
+	index    int
+	template *entityTemplate
+	table    *common.TableSchema
+}
+
+type entityResult struct {
+	index      int
+	props      *TemplateProps
+	table      string
+	outputPath string
+	content    []byte
+	emits      []*emitRequest
+	err        error
 }
 
-func NewGenerator(tmplDir, outputDir, configFile string) (*Generator, error) {
-	config, err := ReadConfig(configFile)
+func NewGenerator(opts *GeneratorOptions) (*Generator, error) {
+	config, err := ReadConfig(opts.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pack, err := openTemplatePack(opts.TemplateDir)
 	if err != nil {
 		return nil, err
 	}
 
 	g := &Generator{
-		config:    config,
-		tmplDir:   tmplDir,
-		outputDir: outputDir,
+		config:     config,
+		configFile: opts.ConfigFile,
+		pack:       pack,
+		tmplFS:     pack.FS,
+		tmplDir:    pack.Dir,
+		outputDir:  opts.OutputDir,
+		jobs:       opts.Jobs,
+		keepGoing:  opts.KeepGoing,
 	}
 	return g, nil
 }
@@ -59,45 +139,149 @@ func (g *Generator) Execute() error {
 		return err
 	}
 
+	return g.render(ctx, nil)
+}
+
+func (g *Generator) render(ctx *genContext, filter templateFilter) error {
 	globalTpls := ctx.Manifest.GlobalTemplates
 	entityTpls := ctx.Manifest.EntityTemplates
 
 	logrus.Infof("GlobalTemplates: %d, EntityTemplates: %d, Tables: %d",
-		len(globalTpls), len(entityTpls), len(ctx.Tables))
+		len(globalTpls), len(entityTpls), len(ctx.DbTables))
 
 	now := time.Now()
 	builtinVars := utils.Variables{
 		"Db":       g.config.Database,
+		"Pack":     g.packInfo(),
 		"DateTime": now.Format(("2006-01-02 15:04:05")),
 		"Date":     now.Format("2006-01-02"),
 	}
 
 	ctx.Vars = utils.MergeVariables(builtinVars, ctx.Manifest.Variables, g.config.Variables)
 
-	err = g.renderGlobalTemplates(ctx)
+	err := common.SetTypeOptions(ctx.Manifest.Types.Merge(g.config.Types))
+	if err != nil {
+		return err
+	}
+
+	err = common.SetIdentifierOptions(ctx.Manifest.Identifiers.Merge(g.config.Identifiers))
+	if err != nil {
+		return err
+	}
+
+	err = common.SetNamingOptions(ctx.Manifest.Naming.Merge(g.config.Naming))
+	if err != nil {
+		return err
+	}
+
+	ctx.Files = nil
+	ctx.Errors = nil
+	ctx.Emits = nil
+
+	ctx.Tables = cloneTables(ctx.DbTables)
+
+	ctx.Scripts, err = newScriptContext(g.tmplFS, g.config.Scripts)
+	if err != nil {
+		return err
+	}
+
+	ctx.Funcs, err = ctx.Scripts.templateFuncs(ctx.Manifest.TemplateFunctions)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := g.newHookRunner(ctx)
 	if err != nil {
 		return err
 	}
+	ctx.Hooks = hooks
 
-	err = g.renderEntityTemplates(ctx)
+	err = hooks.beforeAll(ctx)
 	if err != nil {
 		return err
 	}
+	ctx.Emits = append(ctx.Emits, hooks.takeEmits()...)
+
+	if filter == nil {
+		err = g.writePackInfo()
+		if err != nil {
+			return err
+		}
+
+		err = g.copyStaticFiles(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = g.renderGlobalTemplates(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	err = g.renderEntityTemplates(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	ctx.Emits = append(ctx.Emits, hooks.takeEmits()...)
+	err = g.renderEmitted(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = hooks.afterAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The files emitted by afterAll are rendered after it, before the post
+	// process commands run.
+	ctx.Emits = hooks.takeEmits()
+	err = g.renderEmitted(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = g.runPostCommands(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(ctx.Errors) > 0 {
+		return &GenerateError{Errors: ctx.Errors}
+	}
 	return nil
 }
 
+// fail records a failed rendering. It returns the error if generation has to
+// stop, or nil if it keeps going.
+func (g *Generator) fail(ctx *genContext, err error) error {
+	ctx.Errors = append(ctx.Errors, err)
+	if g.keepGoing {
+		return nil
+	}
+	return err
+}
+
 func (g *Generator) readManifest(ctx *genContext) error {
-	manifestFile := path.Join(g.tmplDir, "manifest.yaml")
-	manifest, err := ReadManifest(manifestFile)
+	manifest, bases, err := loadManifest(g.pack, nil)
 	if err != nil {
 		return err
 	}
+	g.bases = bases
+	g.tmplFS = newLayerFS(g.pack, bases)
+
 	if manifest.GlobalTemplates == nil {
 		manifest.GlobalTemplates = []TemplateProps{}
 	}
 	if manifest.EntityTemplates == nil {
 		manifest.EntityTemplates = []TemplateProps{}
 	}
+	err = g.expandDirectories(manifest)
+	if err != nil {
+		return err
+	}
 	ctx.Manifest = manifest
 	return nil
 }
@@ -109,7 +293,7 @@ func (g *Generator) readDbSchema(ctx *genContext) error {
 
 	provider, err := mysql.NewMySqlSchemaProvider(dbc.Host, dbc.Port, dbc.Username, dbc.Password)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	defer func() {
@@ -124,35 +308,35 @@ func (g *Generator) readDbSchema(ctx *genContext) error {
 		return err
 	}
 
-	ctx.Tables = tables
+	ctx.DbTables = tables
 	return nil
 }
 
-func (g *Generator) renderGlobalTemplates(ctx *genContext) error {
+func (g *Generator) renderGlobalTemplates(ctx *genContext, filter templateFilter) error {
 	logrus.Info("Rendering global templates")
 
-	for _, tplProps := range ctx.Manifest.GlobalTemplates {
-		err := g.renderGlobalTemplate(ctx, &tplProps)
+	for i := range ctx.Manifest.GlobalTemplates {
+		props := &ctx.Manifest.GlobalTemplates[i]
+		if !filter.accept(props) {
+			continue
+		}
+		err := g.renderGlobalTemplate(ctx, ctx.Scripts, props)
 		if err != nil {
-			return err
+			err = g.fail(ctx, err)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func (g *Generator) renderGlobalTemplate(ctx *genContext, props *TemplateProps) error {
+func (g *Generator) renderGlobalTemplate(ctx *genContext, sc *scriptContext, props *TemplateProps) error {
 	logrus.Infof("Rendering global template: %s", props.File)
 
-	file := path.Join(g.tmplDir, props.File)
-	tplBytes, err := os.ReadFile(file)
+	tmpl, err := g.parseTemplate(ctx, props)
 	if err != nil {
-		return err
-	}
-
-	content := string(tplBytes)
-	tmpl, err := template.New(props.File).Parse(content)
-	if err != nil {
-		return err
+		return newRenderError(props, "", "", err)
 	}
 
 	data := &GlobalTemplateData{
@@ -160,20 +344,32 @@ func (g *Generator) renderGlobalTemplate(ctx *genContext, props *TemplateProps)
 		Tables: ctx.Tables,
 	}
 
-	err = g.runGlobalScripts(ctx, props.Script, data)
+	emits, err := g.runGlobalScripts(ctx, sc, props.Script, data)
 	if err != nil {
-		return err
+		return newRenderError(props, "", "", err)
 	}
 
-	outputPath, err := resolveGlobalOutputPath(props.Output, data)
+	outputPath, err := resolveGlobalOutputPath(props.Output, data, ctx.Funcs)
 	if err != nil {
-		return err
+		return newRenderError(props, "", "", err)
 	}
 
-	return g.renderToFile(tmpl, data, outputPath)
+	content, err := g.renderContent(tmpl, props, data)
+	if err == nil {
+		content, err = formatOutput(ctx.Manifest, props, outputPath, content)
+	}
+	if err == nil {
+		err = g.writeFile(ctx, props, outputPath, content)
+	}
+	if err != nil {
+		return newRenderError(props, "", outputPath, err)
+	}
+
+	ctx.Emits = append(ctx.Emits, emits...)
+	return nil
 }
 
-func (g *Generator) runGlobalScripts(ctx *genContext, scriptFile string, data any) error {
+func (g *Generator) runGlobalScripts(ctx *genContext, sc *scriptContext, scriptFile string, data any) ([]*emitRequest, error) {
 	files := []string{}
 
 	for _, file := range ctx.Manifest.GlobalScripts {
@@ -183,79 +379,227 @@ func (g *Generator) runGlobalScripts(ctx *genContext, scriptFile string, data an
 		files = append(files, scriptFile)
 	}
 
-	return g.runScripts(files, "Model", data)
+	return sc.run(files, "Model", data)
 }
 
-func (g *Generator) renderEntityTemplates(ctx *genContext) error {
+func (g *Generator) renderEntityTemplates(ctx *genContext, filter templateFilter) error {
 	logrus.Info("Rendering entity templates")
 
-	for _, tplProps := range ctx.Manifest.EntityTemplates {
-		err := g.renderEntityTemplate(ctx, &tplProps)
+	progress := NewRenderProgress()
+	templates := []*entityTemplate{}
+
+	for i := range ctx.Manifest.EntityTemplates {
+		props := &ctx.Manifest.EntityTemplates[i]
+		if !filter.accept(props) {
+			continue
+		}
+		tmpl, err := g.parseTemplate(ctx, props)
 		if err != nil {
-			return err
+			err = g.fail(ctx, newRenderError(props, "", "", err))
+			if err != nil {
+				progress.Shutdown()
+				return err
+			}
+			continue
 		}
+		templates = append(templates, &entityTemplate{
+			props: props,
+			tmpl:  tmpl,
+			bar:   NewEntityTemplateBar(progress, len(ctx.Tables), props.File),
+		})
 	}
-	return nil
-}
-
-func (g *Generator) renderEntityTemplate(ctx *genContext, props *TemplateProps) error {
-	logrus.Debugf("Rendering entity template: %s", props.File)
 
-	file := path.Join(g.tmplDir, props.File)
-	tplBytes, err := os.ReadFile(file)
-	if err != nil {
-		return err
+	err := g.callBeforeTableHooks(ctx, templates)
+	if err == nil {
+		err = g.runEntityJobs(ctx, templates)
 	}
 
-	content := string(tplBytes)
-	tmpl, err := template.New(props.File).Parse(content)
-	if err != nil {
-		return err
+	for _, et := range templates {
+		if !et.bar.Completed() {
+			et.bar.Abort(false)
+		}
 	}
+	progress.Wait()
 
-	progress, bar := NewEntityTemplateProgress(len(ctx.Tables), props.File)
-	defer progress.Wait()
+	return err
+}
+
+// callBeforeTableHooks calls the beforeTable hook for every table before any
+// entity template is rendered, the afterTable hook is called by runEntityJobs
+// with a copy of the table once all templates of the table are written.
+func (g *Generator) callBeforeTableHooks(ctx *genContext, templates []*entityTemplate) error {
+	if len(templates) <= 0 {
+		return nil
+	}
 
 	for _, table := range ctx.Tables {
-		err = g.renderEntityTemplateWithTable(ctx, tmpl, table, props)
-		bar.Increment()
+		err := ctx.Hooks.beforeTable(table)
 		if err != nil {
-			progress.Shutdown()
-			return err
+			err = g.fail(ctx, err)
+			if err != nil {
+				return err
+			}
 		}
 	}
-
 	return nil
 }
 
-func (g *Generator) renderEntityTemplateWithTable(ctx *genContext, tmpl *template.Template,
-	table *common.TableSchema, props *TemplateProps) error {
+// runEntityJobs renders every table × template pair on a bounded worker pool.
+// Rendered files are written by the calling goroutine in job order, so the
+// output and the reported error are the same as with a sequential run.
+func (g *Generator) runEntityJobs(ctx *genContext, templates []*entityTemplate) error {
+	jobs := make(chan *entityJob)
+	results := make(chan *entityResult)
+
+	var failed atomic.Bool
+	var workers sync.WaitGroup
+
+	for i := 0; i < g.workerCount(); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			g.entityWorker(ctx, jobs, results)
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		index := 0
+		for _, table := range ctx.Tables {
+			for _, et := range templates {
+				if failed.Load() {
+					return
+				}
+				jobs <- &entityJob{index: index, template: et, table: table}
+				index++
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	var firstErr error
+	next := 0
+	pending := map[int]*entityResult{}
+
+	for result := range results {
+		pending[result.index] = result
+		for firstErr == nil {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			err := r.err
+			if err == nil {
+				err = g.writeFile(ctx, r.props, r.outputPath, r.content)
+				if err != nil {
+					err = newRenderError(r.props, r.table, r.outputPath, err)
+				}
+			}
+			if err == nil {
+				ctx.Emits = append(ctx.Emits, r.emits...)
+			}
+			if err != nil {
+				firstErr = g.fail(ctx, err)
+			}
+			if firstErr == nil && (r.index+1)%len(templates) == 0 {
+				err = ctx.Hooks.afterTable(ctx.Tables[r.index/len(templates)])
+				if err != nil {
+					firstErr = g.fail(ctx, err)
+				}
+			}
+			if firstErr != nil {
+				failed.Store(true)
+			}
+		}
+	}
+
+	return firstErr
+}
 
-	logrus.Debugf("Rendering entity template: %s, %s", tmpl.Name(), table.Name)
+func (g *Generator) entityWorker(ctx *genContext, jobs <-chan *entityJob, results chan<- *entityResult) {
+	sc, err := newScriptContext(g.tmplFS, g.config.Scripts)
 
+	for job := range jobs {
+		result := &entityResult{index: job.index, props: job.template.props, table: job.table.Name, err: err}
+		if err == nil {
+			result.outputPath, result.content, result.emits, result.err = g.renderEntityTemplateWithTable(
+				ctx, sc, job.template.tmpl, job.table, job.template.props)
+		}
+		job.template.bar.Increment()
+		results <- result
+	}
+}
+
+func (g *Generator) workerCount() int {
+	if g.jobs > 0 {
+		return g.jobs
+	}
+	return runtime.NumCPU()
+}
+
+func (g *Generator) renderEntityTemplateWithTable(ctx *genContext, sc *scriptContext, tmpl *template.Template,
+	table *common.TableSchema, props *TemplateProps) (string, []byte, []*emitRequest, error) {
+
+	logrus.Debugf("Rendering entity template: %s, %s", props.File, table.Name)
+
+	// Every rendering gets its own copy of the tables, as the scripts of other
+	// renderings run at the same time.
 	data := &EntityTemplateData{
 		Global: &GlobalTemplateData{
 			Vars:   utils.MergeVariables(ctx.Vars),
-			Tables: ctx.Tables,
+			Tables: cloneTables(ctx.Tables),
 		},
 		Vars:  utils.Variables{},
-		Table: table,
+		Table: table.Clone(),
 	}
 
-	err := g.runEntityScripts(ctx, props.Script, data)
+	emits, err := g.runEntityScripts(ctx, sc, props.Script, data)
 	if err != nil {
-		return err
+		return "", nil, nil, newRenderError(props, table.Name, "", err)
 	}
 
-	outputPath, err := resolveEntityOutputPath(props.Output, data)
+	funcs, err := sc.templateFuncs(ctx.Manifest.TemplateFunctions)
 	if err != nil {
-		return err
+		return "", nil, nil, newRenderError(props, table.Name, "", err)
+	}
+
+	outputPath, err := resolveEntityOutputPath(props.Output, data, funcs)
+	if err != nil {
+		return "", nil, nil, newRenderError(props, table.Name, "", err)
 	}
 
-	return g.renderToFile(tmpl, data, outputPath)
+	tmpl, err = sc.bindFuncs(tmpl, ctx.Manifest.TemplateFunctions)
+	if err != nil {
+		return "", nil, nil, newRenderError(props, table.Name, "", err)
+	}
+
+	content, err := g.renderContent(tmpl, props, data)
+	if err == nil {
+		content, err = formatOutput(ctx.Manifest, props, outputPath, content)
+	}
+	if err != nil {
+		return "", nil, nil, newRenderError(props, table.Name, outputPath, err)
+	}
+
+	return outputPath, content, emits, nil
+}
+
+func cloneTables(tables []*common.TableSchema) []*common.TableSchema {
+	clones := make([]*common.TableSchema, len(tables))
+	for i, table := range tables {
+		clones[i] = table.Clone()
+	}
+	return clones
 }
 
-func (g *Generator) runEntityScripts(ctx *genContext, scriptFile string, data any) error {
+func (g *Generator) runEntityScripts(ctx *genContext, sc *scriptContext, scriptFile string, data any) ([]*emitRequest, error) {
 	files := []string{}
 
 	for _, file := range ctx.Manifest.EntityScripts {
@@ -265,85 +609,64 @@ func (g *Generator) runEntityScripts(ctx *genContext, scriptFile string, data an
 		files = append(files, scriptFile)
 	}
 
-	return g.runScripts(files, "Model", data)
+	return sc.run(files, "Model", data)
 }
 
-func (g *Generator) renderToFile(tmpl *template.Template, data any, outputPath string) error {
-	outputPath = path.Join(g.outputDir, outputPath)
-	outputDir := path.Dir(outputPath)
-	err := os.MkdirAll(outputDir, 0o755)
-	if err != nil {
-		return err
+func (g *Generator) parseTemplate(ctx *genContext, props *TemplateProps) (*template.Template, error) {
+	if props.verbatim {
+		return nil, nil
 	}
 
-	file, err := os.Create(outputPath)
+	tplBytes, err := fs.ReadFile(g.tmplFS, path.Clean(props.File))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	defer func(f *os.File) {
-		if e := f.Close(); e != nil {
-			logrus.Error(e)
-		}
-	}(file)
-
-	writer := bufio.NewWriter(file)
-	defer func(w *bufio.Writer) {
-		if e := w.Flush(); e != nil {
-			logrus.Error(e)
-		}
-	}(writer)
-
-	return tmpl.Execute(writer, data)
+	content := string(tplBytes)
+	return template.New(props.File).Funcs(ctx.Funcs).Parse(content)
 }
 
-func (g *Generator) runScripts(scriptFiles []string, varName string, data any) error {
-	if scriptFiles == nil || len(scriptFiles) <= 0 {
-		return nil
+// renderContent renders the template with the data, files copied verbatim from
+// a template directory are returned as they are.
+func (g *Generator) renderContent(tmpl *template.Template, props *TemplateProps, data any) ([]byte, error) {
+	if props.verbatim {
+		return fs.ReadFile(g.tmplFS, path.Clean(props.File))
 	}
+	return renderTemplate(tmpl, data)
+}
 
-	scripts := []string{}
-
-	for _, name := range scriptFiles {
-		filePath := filepath.Join(g.tmplDir, name)
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			return err
-		}
-		scripts = append(scripts, string(content))
-	}
-
-	script := strings.Join(scripts, "\n\n")
-	vm := otto.New()
-
-	fns := &JsFunctions{}
-	err := vm.Set("Utils", fns)
-	if err != nil {
-		return err
-	}
-	err = vm.Set("F", fns)
+func (g *Generator) writeFile(ctx *genContext, props *TemplateProps, outputPath string, content []byte) error {
+	fullPath := path.Join(g.outputDir, outputPath)
+	outputDir := path.Dir(fullPath)
+	err := os.MkdirAll(outputDir, 0o755)
 	if err != nil {
 		return err
 	}
 
-	err = vm.Set(varName, data)
+	err = os.WriteFile(fullPath, content, 0o644)
 	if err != nil {
 		return err
 	}
 
-	_, err = vm.Run(script)
+	ctx.Files = append(ctx.Files, &writtenFile{
+		Path:     path.Clean(outputPath),
+		Template: props,
+	})
+	return nil
+}
+
+func renderTemplate(tmpl *template.Template, data any) ([]byte, error) {
+	var arr []byte
+	buf := bytes.NewBuffer(arr)
+	err := tmpl.Execute(buf, data)
 	if err != nil {
-		if ottoErr, ok := err.(*otto.Error); ok {
-			logrus.Errorf("Script error: %s, %s", scriptFiles, ottoErr.String())
-		}
-		return err
+		return nil, err
 	}
-
-	return nil
+	return buf.Bytes(), nil
 }
 
-func resolveGlobalOutputPath(pattern string, data *GlobalTemplateData) (string, error) {
-	tmpl, err := template.New("path").Parse(pattern)
+func resolveGlobalOutputPath(pattern string, data *GlobalTemplateData, funcs template.FuncMap) (string, error) {
+	tmpl, err := template.New("path").Funcs(funcs).Parse(pattern)
 	if err != nil {
 		return "", err
 	}
@@ -358,8 +681,8 @@ func resolveGlobalOutputPath(pattern string, data *GlobalTemplateData) (string,
 	return buf.String(), nil
 }
 
-func resolveEntityOutputPath(pattern string, data *EntityTemplateData) (string, error) {
-	tmpl, err := template.New("path").Parse(pattern)
+func resolveEntityOutputPath(pattern string, data *EntityTemplateData, funcs template.FuncMap) (string, error) {
+	tmpl, err := template.New("path").Funcs(funcs).Parse(pattern)
 	if err != nil {
 		return "", err
 	}
@@ -374,10 +697,12 @@ func resolveEntityOutputPath(pattern string, data *EntityTemplateData) (string,
 	return buf.String(), nil
 }
 
-func NewEntityTemplateProgress(total int, name string) (*mpb.Progress, *mpb.Bar) {
-	progress := mpb.New(mpb.WithWidth(20))
+func NewRenderProgress() *mpb.Progress {
+	return mpb.New(mpb.WithWidth(20))
+}
 
-	bar := progress.New(int64(total),
+func NewEntityTemplateBar(progress *mpb.Progress, total int, name string) *mpb.Bar {
+	return progress.New(int64(total),
 		mpb.BarStyle(),
 		mpb.PrependDecorators(
 			decor.CountersNoUnit("Rendering: %d/%d"),
@@ -385,6 +710,4 @@ func NewEntityTemplateProgress(total int, name string) (*mpb.Progress, *mpb.Bar)
 		mpb.AppendDecorators(
 			decor.Name(name),
 		))
-
-	return progress, bar
 }
@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"crudify/schema/common"
+)
+
+// newTestGenerator returns a generator rendering the template pack files into
+// a temporary directory, with the manifest read.
+func newTestGenerator(t *testing.T, files fstest.MapFS, jobs int) (*Generator, *genContext) {
+	t.Helper()
+
+	pack := &templatePack{FS: files, Info: PackInfo{Source: "test"}}
+	g := &Generator{
+		config:    &ConfigModel{},
+		pack:      pack,
+		tmplFS:    pack.FS,
+		outputDir: t.TempDir(),
+		jobs:      jobs,
+	}
+
+	ctx := new(genContext)
+	err := g.readManifest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g, ctx
+}
+
+func testTables(n int) []*common.TableSchema {
+	tables := []*common.TableSchema{}
+	for i := 0; i < n; i++ {
+		tables = append(tables, &common.TableSchema{
+			Name: fmt.Sprintf("table_%d", i),
+			Columns: []*common.ColumnSchema{
+				{Name: "id", DataType: common.DataTypeInt64, IsPrimaryKey: true},
+				{Name: "name", DataType: common.DataTypeString},
+			},
+		})
+	}
+	return tables
+}
+
+func readOutput(t *testing.T, g *Generator, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(g.outputDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// TestEntityScriptsChangeGlobalTables runs entity scripts changing the global
+// tables on several workers, run with -race to detect shared tables.
+func TestEntityScriptsChangeGlobalTables(t *testing.T) {
+	files := fstest.MapFS{
+		"manifest.yaml": {Data: []byte(`
+entity-scripts:
+  - scripts/entity.js
+entity-templates:
+  - file: templates/entity.tmpl
+    output: "{{.Table.Name}}.txt"
+`)},
+		"scripts/entity.js": {Data: []byte(`
+Model.Global.Tables.forEach(t => t.Comment = Model.Table.Name);
+Model.Table.Columns.forEach(c => c.Comment = Model.Table.Name);
+`)},
+		"templates/entity.tmpl": {Data: []byte(`{{range .Global.Tables}}{{.Comment}} {{end}}`)},
+	}
+
+	g, ctx := newTestGenerator(t, files, 4)
+	ctx.DbTables = testTables(8)
+
+	err := g.render(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range ctx.DbTables {
+		want := ""
+		for range ctx.DbTables {
+			want += table.Name + " "
+		}
+		if got := readOutput(t, g, table.Name+".txt"); got != want {
+			t.Errorf("%s.txt = %q, want %q", table.Name, got, want)
+		}
+	}
+	for _, table := range ctx.Tables {
+		if table.Comment != "" {
+			t.Errorf("table %s changed by an entity script: %q", table.Name, table.Comment)
+		}
+	}
+}
+
+func TestAfterAllEmit(t *testing.T) {
+	files := fstest.MapFS{
+		"manifest.yaml": {Data: []byte(`
+hooks: scripts/hooks.js
+global-templates:
+  - file: templates/a.tmpl
+    output: a.txt
+`)},
+		"scripts/hooks.js": {Data: []byte(`
+exports.afterAll = report => Output.emit('count.txt', 'templates/count.tmpl', {Count: report.Files.length});
+`)},
+		"templates/a.tmpl":     {Data: []byte(`a`)},
+		"templates/count.tmpl": {Data: []byte(`{{.Count}}`)},
+	}
+
+	g, ctx := newTestGenerator(t, files, 1)
+	err := g.render(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readOutput(t, g, "count.txt"); got != "1" {
+		t.Errorf("count.txt = %q, want %q", got, "1")
+	}
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"fmt"
+
+	"crudify/schema/common"
+	"github.com/dop251/goja"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HookBeforeAll   = "beforeAll"
+	HookBeforeTable = "beforeTable"
+	HookAfterTable  = "afterTable"
+	HookAfterAll    = "afterAll"
+)
+
+// hookRunner calls the lifecycle functions exported by the manifest's hooks
+// module. The module is loaded once per render into its own runtime, so its
+// module-level state is kept from beforeAll to afterAll. Hooks are only called
+// from the generator's goroutine.
+type hookRunner struct {
+	sc      *scriptContext
+	file    string
+	vm      *goja.Runtime
+	exports *goja.Object
+	output  *outputApi
+	schema  *schemaApi
+}
+
+// newHookRunner loads the hooks module, it returns nil if the manifest has no
+// hooks. The methods of a nil runner do nothing.
+func (g *Generator) newHookRunner(ctx *genContext) (*hookRunner, error) {
+	if ctx.Manifest.Hooks == "" {
+		return nil, nil
+	}
+
+	sc, err := newScriptContext(g.tmplFS, g.config.Scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := sc.newRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &hookRunner{
+		sc:     sc,
+		file:   ctx.Manifest.Hooks,
+		vm:     vm,
+		output: newOutputApi(vm, nil),
+		schema: &schemaApi{vm: vm, ctx: ctx},
+	}
+
+	outputObj, err := h.output.object()
+	if err != nil {
+		return nil, err
+	}
+	err = vm.Set("Output", outputObj)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaObj, err := h.schema.object()
+	if err != nil {
+		return nil, err
+	}
+	err = vm.Set("Schema", schemaObj)
+	if err != nil {
+		return nil, err
+	}
+
+	require, _ := goja.AssertFunction(vm.Get("require"))
+	stop := sc.startTimeout(vm)
+	exports, err := require(goja.Undefined(), vm.ToValue("./"+h.file))
+	stop()
+	if err != nil {
+		return nil, sc.newScriptError(h.file, err)
+	}
+	h.exports = exports.ToObject(vm)
+
+	return h, nil
+}
+
+func (h *hookRunner) call(name string, arg any) error {
+	if h == nil {
+		return nil
+	}
+
+	fn, ok := goja.AssertFunction(h.exports.Get(name))
+	if !ok {
+		return nil
+	}
+
+	logrus.Debugf("Calling hook: %s", name)
+
+	stop := h.sc.startTimeout(h.vm)
+	_, err := fn(goja.Undefined(), h.vm.ToValue(arg))
+	stop()
+	if err != nil {
+		return fmt.Errorf("hook %s: %w", name, h.sc.newScriptError(h.file, err))
+	}
+	return nil
+}
+
+// takeEmits returns the output files requested by the hooks so far.
+func (h *hookRunner) takeEmits() []*emitRequest {
+	if h == nil {
+		return nil
+	}
+	emits := h.output.emits
+	h.output.emits = nil
+	return emits
+}
+
+func (h *hookRunner) beforeAll(ctx *genContext) error {
+	hookCtx := &HookContext{
+		Vars:   ctx.Vars,
+		Tables: ctx.Tables,
+	}
+	if h == nil {
+		return nil
+	}
+
+	err := h.call(HookBeforeAll, hookCtx)
+	h.schema.locked = true
+	return err
+}
+
+func (h *hookRunner) beforeTable(table *common.TableSchema) error {
+	return h.call(HookBeforeTable, table)
+}
+
+// afterTable passes the hook a copy of the table, as the workers may still be
+// rendering other tables with the schema. Changes to the copy are discarded.
+func (h *hookRunner) afterTable(table *common.TableSchema) error {
+	return h.call(HookAfterTable, table.Clone())
+}
+
+func (h *hookRunner) afterAll(ctx *genContext) error {
+	report := &HookReport{
+		Files:  []string{},
+		Errors: []string{},
+		Tables: ctx.Tables,
+	}
+	for _, file := range ctx.Files {
+		report.Files = append(report.Files, file.Path)
+	}
+	for _, err := range ctx.Errors {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	return h.call(HookAfterAll, report)
+}
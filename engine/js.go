@@ -60,3 +60,99 @@ func (f *JsFunctions) ToPluralCamelCase(value string) string {
 func (f *JsFunctions) ToPluralPascalCase(value string) string {
 	return utils.ToPluralPascalCase(value)
 }
+
+func (f *JsFunctions) SplitWordsInitialisms(value string, lower bool) []string {
+	return utils.SplitWordsInitialisms(value, lower)
+}
+
+func (f *JsFunctions) ToCamelCaseInitialisms(value string) string {
+	return utils.ToCamelCaseInitialisms(value)
+}
+
+func (f *JsFunctions) ToPascalCaseInitialisms(value string) string {
+	return utils.ToPascalCaseInitialisms(value)
+}
+
+func (f *JsFunctions) ToSnakeCaseInitialisms(value string) string {
+	return utils.ToSnakeCaseInitialisms(value)
+}
+
+func (f *JsFunctions) ToKebabCaseInitialisms(value string) string {
+	return utils.ToKebabCaseInitialisms(value)
+}
+
+func (f *JsFunctions) ToSingularSnakeCase(value string) string {
+	return utils.ToSingularSnakeCase(value)
+}
+
+func (f *JsFunctions) ToSingularKebabCase(value string) string {
+	return utils.ToSingularKebabCase(value)
+}
+
+func (f *JsFunctions) ToSingularCamelCase(value string) string {
+	return utils.ToSingularCamelCase(value)
+}
+
+func (f *JsFunctions) ToSingularPascalCase(value string) string {
+	return utils.ToSingularPascalCase(value)
+}
+
+func (f *JsFunctions) ToScreamingSnakeCase(value string) string {
+	return utils.ToScreamingSnakeCase(value)
+}
+
+func (f *JsFunctions) ToPluralScreamingSnakeCase(value string) string {
+	return utils.ToPluralScreamingSnakeCase(value)
+}
+
+func (f *JsFunctions) ToSingularScreamingSnakeCase(value string) string {
+	return utils.ToSingularScreamingSnakeCase(value)
+}
+
+func (f *JsFunctions) ToDotCase(value string) string {
+	return utils.ToDotCase(value)
+}
+
+func (f *JsFunctions) ToPluralDotCase(value string) string {
+	return utils.ToPluralDotCase(value)
+}
+
+func (f *JsFunctions) ToSingularDotCase(value string) string {
+	return utils.ToSingularDotCase(value)
+}
+
+func (f *JsFunctions) ToTitleCase(value string) string {
+	return utils.ToTitleCase(value)
+}
+
+func (f *JsFunctions) ToPluralTitleCase(value string) string {
+	return utils.ToPluralTitleCase(value)
+}
+
+func (f *JsFunctions) ToSingularTitleCase(value string) string {
+	return utils.ToSingularTitleCase(value)
+}
+
+func (f *JsFunctions) ToPathCase(value string) string {
+	return utils.ToPathCase(value)
+}
+
+func (f *JsFunctions) ToPluralPathCase(value string) string {
+	return utils.ToPluralPathCase(value)
+}
+
+func (f *JsFunctions) ToSingularPathCase(value string) string {
+	return utils.ToSingularPathCase(value)
+}
+
+func (f *JsFunctions) ToFlatCase(value string) string {
+	return utils.ToFlatCase(value)
+}
+
+func (f *JsFunctions) ToPluralFlatCase(value string) string {
+	return utils.ToPluralFlatCase(value)
+}
+
+func (f *JsFunctions) ToSingularFlatCase(value string) string {
+	return utils.ToSingularFlatCase(value)
+}
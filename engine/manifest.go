@@ -1,27 +1,98 @@
 package engine
 
 import (
-	"os"
+	"io/fs"
 
+	"crudify/schema/common"
 	"gopkg.in/yaml.v3"
 )
 
+const ManifestFileName = "manifest.yaml"
+
+// PostProcessProps is a post-processing step. A step either formats each
+// output file in-process with a built-in formatter (go, json, yaml) or runs a
+// shell command once after the whole generation. Files is an optional glob
+// matched against output paths, "**" matches any number of directories.
+type PostProcessProps struct {
+	Files   string `yaml:"files"`
+	Format  string `yaml:"format"`
+	Command string `yaml:"command"`
+}
+
+// TemplateProps is a template of a manifest. A manifest extending another one
+// overrides the inherited template with the same ID, or with the same File if
+// the template has no ID, and removes it if Disabled is set.
 type TemplateProps struct {
-	File   string `yaml:"file"`
-	Script string `yaml:"script"`
+	ID          string             `yaml:"id"`
+	File        string             `yaml:"file"`
+	Script      string             `yaml:"script"`
+	Output      string             `yaml:"output"`
+	PostProcess []PostProcessProps `yaml:"post-process"`
+	Disabled    bool               `yaml:"disabled"`
+
+	// verbatim marks a non-template file of a template directory, which is
+	// copied without rendering.
+	verbatim bool
+	// directory is the template directory the template was expanded from.
+	directory *DirectoryProps
+}
+
+// StaticProps copies the template files matching the Files glob verbatim into
+// the Output directory. The glob is matched against the whole path relative to
+// the template directory. Paths are kept relative to the part of the glob
+// before the first wildcard, or to the directory of a plain file path.
+type StaticProps struct {
+	Files  string `yaml:"files"`
 	Output string `yaml:"output"`
 }
 
+// DirectoryProps mirrors the template subtree Dir into the Output directory.
+// Every "*.tmpl" file is rendered and written without the suffix, other files
+// are copied verbatim. Path names may contain template actions. With Entity set
+// the tree is rendered once per table. Commands of PostProcess run once with
+// all files written from the tree.
+type DirectoryProps struct {
+	Dir         string             `yaml:"dir"`
+	Output      string             `yaml:"output"`
+	Entity      bool               `yaml:"entity"`
+	Script      string             `yaml:"script"`
+	PostProcess []PostProcessProps `yaml:"post-process"`
+}
+
 type ManifestModel struct {
-	Variables       map[string]any  `yaml:"variables"`
-	GlobalScripts   []string        `yaml:"global-scripts"`
-	GlobalTemplates []TemplateProps `yaml:"global-templates"`
-	EntityScripts   []string        `yaml:"entity-scripts"`
-	EntityTemplates []TemplateProps `yaml:"entity-templates"`
+	// Extends is the template pack this pack is based on, a template
+	// directory, archive, git repository or builtin pack as for the template
+	// source of the generator. Relative paths are relative to the directory
+	// of the manifest, or of the archive or repository containing it.
+	Extends         string             `yaml:"extends"`
+	Variables       map[string]any     `yaml:"variables"`
+	GlobalScripts   []string           `yaml:"global-scripts"`
+	GlobalTemplates []TemplateProps    `yaml:"global-templates"`
+	EntityScripts   []string           `yaml:"entity-scripts"`
+	EntityTemplates []TemplateProps    `yaml:"entity-templates"`
+	PostProcess     []PostProcessProps `yaml:"post-process"`
+	Static          []StaticProps      `yaml:"static"`
+	Directories     []DirectoryProps   `yaml:"directories"`
+	// Hooks is a script module exporting any of the beforeAll(ctx),
+	// beforeTable(table), afterTable(table) and afterAll(report) functions.
+	Hooks string `yaml:"hooks"`
+	// TemplateFunctions are script modules whose exported functions are
+	// added to the template functions.
+	TemplateFunctions []string `yaml:"template-functions"`
+	// Types configures the language types of columns, the types of the config
+	// file take precedence.
+	Types common.TypeOptions `yaml:"types"`
+	// Identifiers configures the identifier rules of SafeIdentifier per
+	// language, the rules of the config file take precedence.
+	Identifiers common.IdentifierOptions `yaml:"identifiers"`
+	// Naming configures the initialism-aware naming and the inflections, the
+	// lists of the config file take precedence.
+	Naming common.NamingOptions `yaml:"naming"`
 }
 
-func ReadManifest(filename string) (*ManifestModel, error) {
-	data, err := os.ReadFile(filename)
+// ReadManifest reads the manifest of the template pack fsys.
+func ReadManifest(fsys fs.FS) (*ManifestModel, error) {
+	data, err := fs.ReadFile(fsys, ManifestFileName)
 	if err != nil {
 		return nil, err
 	}
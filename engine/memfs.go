@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// memFS is a read-only file system of files held in memory, keyed by their
+// slash separated path. Directories are implied by the paths of the files.
+type memFS map[string]*memFile
+
+type memFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (m memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if file, ok := m[name]; ok {
+		info := &memInfo{name: path.Base(name), size: int64(len(file.data)), mode: file.mode, modTime: file.modTime}
+		return &openMemFile{Reader: bytes.NewReader(file.data), info: info}, nil
+	}
+
+	entries, err := m.ReadDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &openMemDir{info: &memInfo{name: path.Base(name), mode: fs.ModeDir | 0o555}, entries: entries}, nil
+}
+
+func (m memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+
+	entries := []fs.DirEntry{}
+	for p, file := range m {
+		rest, ok := strings.CutPrefix(p, prefix)
+		if !ok {
+			continue
+		}
+		child, _, isDir := strings.Cut(rest, "/")
+		if slices.ContainsFunc(entries, func(e fs.DirEntry) bool { return e.Name() == child }) {
+			continue
+		}
+		if isDir {
+			entries = append(entries, &memInfo{name: child, mode: fs.ModeDir | 0o555})
+		} else {
+			entries = append(entries, &memInfo{name: child, size: int64(len(file.data)), mode: file.mode, modTime: file.modTime})
+		}
+	}
+
+	if len(entries) <= 0 && name != "." {
+		if _, ok := m[name]; !ok {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+		}
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
+
+// memInfo is the fs.FileInfo and fs.DirEntry of a file or directory of a
+// memFS.
+type memInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *memInfo) Name() string               { return i.name }
+func (i *memInfo) Size() int64                { return i.size }
+func (i *memInfo) Mode() fs.FileMode          { return i.mode }
+func (i *memInfo) ModTime() time.Time         { return i.modTime }
+func (i *memInfo) IsDir() bool                { return i.mode.IsDir() }
+func (i *memInfo) Sys() any                   { return nil }
+func (i *memInfo) Type() fs.FileMode          { return i.mode.Type() }
+func (i *memInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+type openMemFile struct {
+	*bytes.Reader
+	info *memInfo
+}
+
+func (f *openMemFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *openMemFile) Close() error               { return nil }
+
+type openMemDir struct {
+	info    *memInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *openMemDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *openMemDir) Close() error               { return nil }
+
+func (d *openMemDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+func (d *openMemDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.entries[d.offset:]
+	if n > 0 && len(entries) <= 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
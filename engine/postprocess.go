@@ -0,0 +1,189 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+type formatterFunc func(content []byte) ([]byte, error)
+
+var formatters = map[string]formatterFunc{
+	"go":   formatGo,
+	"json": formatJson,
+	"yaml": formatYaml,
+}
+
+// formatOutput applies the format steps of the template and then the format
+// steps of the manifest whose file glob matches the output path.
+func formatOutput(manifest *ManifestModel, props *TemplateProps, outputPath string, content []byte) ([]byte, error) {
+	if props.verbatim {
+		return content, nil
+	}
+
+	steps := append([]PostProcessProps{}, props.PostProcess...)
+	steps = append(steps, manifest.PostProcess...)
+
+	for _, step := range steps {
+		if step.Format == "" || !matchGlob(step.Files, outputPath) {
+			continue
+		}
+		formatter, ok := formatters[step.Format]
+		if !ok {
+			return nil, fmt.Errorf("unknown formatter: %s", step.Format)
+		}
+		formatted, err := formatter(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s formatter failed: %w", step.Format, err)
+		}
+		content = formatted
+	}
+
+	return content, nil
+}
+
+func formatGo(content []byte) ([]byte, error) {
+	return format.Source(content)
+}
+
+func formatJson(content []byte) ([]byte, error) {
+	var arr []byte
+	buf := bytes.NewBuffer(arr)
+	err := json.Indent(buf, bytes.TrimSpace(content), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func formatYaml(content []byte) ([]byte, error) {
+	var arr []byte
+	buf := bytes.NewBuffer(arr)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = encoder.Encode(&node)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// runPostCommands runs the command steps once the whole generation is done.
+// Template steps get the files written by their template, the steps of a
+// template directory get the files written by all templates of the directory
+// and manifest steps get all written files matching their glob.
+func (g *Generator) runPostCommands(ctx *genContext) error {
+	templates := []*TemplateProps{}
+	seen := map[any]bool{}
+	for _, file := range ctx.Files {
+		if file.Template != nil && !seen[postProcessOwner(file.Template)] {
+			seen[postProcessOwner(file.Template)] = true
+			templates = append(templates, file.Template)
+		}
+	}
+
+	for _, props := range templates {
+		for _, step := range props.PostProcess {
+			err := g.runPostCommand(ctx, &step, props)
+			if err != nil {
+				if props.directory != nil {
+					return fmt.Errorf("directory %s: %w", props.directory.Dir, err)
+				}
+				return fmt.Errorf("template %s: %w", props.File, err)
+			}
+		}
+	}
+
+	for _, step := range ctx.Manifest.PostProcess {
+		err := g.runPostCommand(ctx, &step, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postProcessOwner returns the template directory the template was expanded
+// from, or the template itself, whose command steps apply to the file.
+func postProcessOwner(props *TemplateProps) any {
+	if props.directory != nil {
+		return props.directory
+	}
+	return props
+}
+
+func (g *Generator) runPostCommand(ctx *genContext, step *PostProcessProps, props *TemplateProps) error {
+	if step.Command == "" {
+		return nil
+	}
+
+	files := []string{}
+	for _, file := range ctx.Files {
+		if props != nil && (file.Template == nil || postProcessOwner(file.Template) != postProcessOwner(props)) {
+			continue
+		}
+		if matchGlob(step.Files, file.Path) {
+			files = append(files, file.Path)
+		}
+	}
+	if len(files) <= 0 {
+		return nil
+	}
+
+	logrus.Infof("Running post-process command: %s (%d files)", step.Command, len(files))
+
+	outputDir, err := filepath.Abs(g.outputDir)
+	if err != nil {
+		return err
+	}
+
+	// The files are appended to the command as arguments, relative to the
+	// output directory which is also the working directory.
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", append([]string{"/C", step.Command}, files...)...)
+	} else {
+		cmd = exec.Command("sh", append([]string{"-c", step.Command + ` "$@"`, "sh"}, files...)...)
+	}
+	cmd.Dir = outputDir
+	cmd.Env = append(os.Environ(),
+		"CRUDIFY_OUTPUT_DIR="+outputDir,
+		"CRUDIFY_FILES="+strings.Join(files, "\n"))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("post-process command failed: %s: %w", step.Command, err)
+	}
+	return nil
+}
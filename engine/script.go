@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"text/template"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/parser"
+)
+
+// defaultScriptTimeout is the time a single script run, hook or template
+// function call may take unless configured otherwise.
+const defaultScriptTimeout = 30 * time.Second
+
+// scriptContext runs scripts and caches their compiled programs. A goja
+// runtime is not goroutine-safe, so every rendering worker creates its own
+// context, and every run gets a fresh runtime so that scripts can not leak
+// globals into later renders.
+type scriptContext struct {
+	fsys     fs.FS
+	props    ScriptProps
+	programs map[string]*goja.Program
+	modules  map[string]*goja.Program
+	funcs    template.FuncMap
+	bound    map[*template.Template]*template.Template
+}
+
+func newScriptContext(fsys fs.FS, props ScriptProps) (*scriptContext, error) {
+	sc := &scriptContext{
+		fsys:     fsys,
+		props:    props,
+		programs: map[string]*goja.Program{},
+		modules:  map[string]*goja.Program{},
+		bound:    map[*template.Template]*template.Template{},
+	}
+	return sc, nil
+}
+
+func (sc *scriptContext) newRuntime() (*goja.Runtime, error) {
+	vm := goja.New()
+	if sc.props.MaxCallStackSize > 0 {
+		vm.SetMaxCallStackSize(sc.props.MaxCallStackSize)
+	}
+
+	fns := &JsFunctions{}
+	err := vm.Set("Utils", fns)
+	if err != nil {
+		return nil, err
+	}
+	err = vm.Set("F", fns)
+	if err != nil {
+		return nil, err
+	}
+
+	fsObj, err := newFsApi(sc, vm).object()
+	if err != nil {
+		return nil, err
+	}
+	err = vm.Set("Fs", fsObj)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := newModuleLoader(sc, vm)
+	err = vm.Set("require", loader.requireFunc("."))
+	if err != nil {
+		return nil, err
+	}
+
+	return vm, nil
+}
+
+// run executes the script files one after another in the same runtime, with
+// the data bound to the global variable varName. It returns the extra output
+// files requested by the scripts.
+func (sc *scriptContext) run(scriptFiles []string, varName string, data any) ([]*emitRequest, error) {
+	if scriptFiles == nil || len(scriptFiles) <= 0 {
+		return nil, nil
+	}
+
+	programs := []*goja.Program{}
+	for _, name := range scriptFiles {
+		program, err := sc.compile(name)
+		if err != nil {
+			return nil, err
+		}
+		programs = append(programs, program)
+	}
+
+	vm, err := sc.newRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	err = vm.Set(varName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	output := newOutputApi(vm, data)
+	outputObj, err := output.object()
+	if err != nil {
+		return nil, err
+	}
+	err = vm.Set("Output", outputObj)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, program := range programs {
+		stop := sc.startTimeout(vm)
+		_, err = vm.RunProgram(program)
+		stop()
+		if err != nil {
+			return nil, sc.newScriptError(scriptFiles[i], err)
+		}
+	}
+
+	return output.emits, nil
+}
+
+// startTimeout interrupts the runtime once the script timeout has expired.
+// The returned function stops the timer and has to be called as soon as the
+// script returns, so that the runtime can be used again.
+func (sc *scriptContext) startTimeout(vm *goja.Runtime) func() {
+	timeout := sc.props.Timeout
+	if timeout == 0 {
+		timeout = defaultScriptTimeout
+	}
+	if timeout < 0 {
+		return func() {}
+	}
+
+	timer := time.AfterFunc(timeout, func() {
+		vm.Interrupt(fmt.Errorf("script timed out after %s", timeout))
+	})
+	return func() {
+		timer.Stop()
+		vm.ClearInterrupt()
+	}
+}
+
+func (sc *scriptContext) compile(name string) (*goja.Program, error) {
+	if program, ok := sc.programs[name]; ok {
+		return program, nil
+	}
+
+	content, err := fs.ReadFile(sc.fsys, path.Clean(name))
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err := parser.ParseFile(nil, name, string(content), 0)
+	if err != nil {
+		return nil, sc.newScriptError(name, err)
+	}
+
+	program, err := goja.CompileAST(ast, false)
+	if err != nil {
+		return nil, sc.newScriptError(name, err)
+	}
+
+	sc.programs[name] = program
+	return program, nil
+}
+
+// newScriptError locates a compile error or an exception thrown by a script.
+// Exceptions are located by the innermost stack frame of a script file, which
+// may be another file than the one being run.
+func (sc *scriptContext) newScriptError(name string, err error) *ScriptError {
+	var scriptErr *ScriptError
+	if errors.As(err, &scriptErr) {
+		return scriptErr
+	}
+
+	e := &ScriptError{
+		File:    name,
+		Message: err.Error(),
+	}
+
+	var parseErrs parser.ErrorList
+	var syntaxErr *goja.CompilerSyntaxError
+	var interrupted *goja.InterruptedError
+	var overflow *goja.StackOverflowError
+	var exception *goja.Exception
+
+	if errors.As(err, &parseErrs) && len(parseErrs) > 0 {
+		e.Message = parseErrs[0].Message
+		e.Line, e.Column = parseErrs[0].Position.Line, parseErrs[0].Position.Column
+	} else if errors.As(err, &syntaxErr) {
+		e.Message = syntaxErr.Message
+		if syntaxErr.File != nil {
+			pos := syntaxErr.File.Position(syntaxErr.Offset)
+			e.Line, e.Column = pos.Line, pos.Column
+		}
+	} else if errors.As(err, &interrupted) {
+		e.Message = fmt.Sprint(interrupted.Value())
+		e.locate(interrupted.Stack())
+	} else if errors.As(err, &overflow) {
+		e.Message = "maximum call stack size exceeded"
+		e.locate(overflow.Stack())
+	} else if errors.As(err, &exception) {
+		e.Message = exception.Value().String()
+		e.locate(exception.Stack())
+	}
+
+	if _, ok := sc.modules[e.File]; ok && e.Line == 1 {
+		e.Column -= len(moduleWrapperPrefix)
+	}
+	return e
+}
+
+// locate sets the position of the error to the innermost stack frame of a
+// script file.
+func (e *ScriptError) locate(stack []goja.StackFrame) {
+	for _, frame := range stack {
+		pos := frame.Position()
+		if pos.Filename != "" && pos.Line > 0 {
+			e.File, e.Line, e.Column = pos.Filename, pos.Line, pos.Column
+			return
+		}
+	}
+}
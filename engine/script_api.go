@@ -0,0 +1,253 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+
+	"crudify/schema/common"
+	"github.com/dop251/goja"
+)
+
+// outputApi is the Output object of a script runtime.
+type outputApi struct {
+	vm    *goja.Runtime
+	model any
+	emits []*emitRequest
+}
+
+func newOutputApi(vm *goja.Runtime, model any) *outputApi {
+	return &outputApi{vm: vm, model: model}
+}
+
+// newApiObject returns a JS object with the given functions. The function
+// tables of the script APIs are also used to generate their type declarations.
+func newApiObject(vm *goja.Runtime, fns map[string]any) (*goja.Object, error) {
+	obj := vm.NewObject()
+	for name, fn := range fns {
+		err := obj.Set(name, fn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+func (o *outputApi) functions() map[string]any {
+	return map[string]any{
+		"emit": o.emit,
+	}
+}
+
+func (o *outputApi) object() (*goja.Object, error) {
+	return newApiObject(o.vm, o.functions())
+}
+
+// emit requests an output file rendered from the template with the optional
+// data. Without data the template is rendered with the model of the current
+// script. The output path is relative to the output directory and must not
+// leave it.
+func (o *outputApi) emit(outputPath, templateName string, data ...goja.Value) {
+	output := path.Clean(outputPath)
+	if !fs.ValidPath(output) || output == "." {
+		panic(o.vm.NewTypeError("Output.emit: outside of the output directory: %s", outputPath))
+	}
+
+	name := path.Clean(templateName)
+	if !fs.ValidPath(name) {
+		panic(o.vm.NewTypeError("Output.emit: invalid template name: %s", templateName))
+	}
+
+	var value any = o.model
+	if len(data) > 0 && data[0] != nil && !goja.IsUndefined(data[0]) {
+		value = data[0].Export()
+	}
+
+	o.emits = append(o.emits, &emitRequest{
+		Path:     output,
+		Template: name,
+		Data:     value,
+	})
+}
+
+// fsApi is the Fs object of a script runtime. It gives read-only access to the
+// files of the template directory.
+type fsApi struct {
+	sc *scriptContext
+	vm *goja.Runtime
+}
+
+func newFsApi(sc *scriptContext, vm *goja.Runtime) *fsApi {
+	return &fsApi{sc: sc, vm: vm}
+}
+
+func (f *fsApi) functions() map[string]any {
+	return map[string]any{
+		"read":     f.read,
+		"exists":   f.exists,
+		"readJson": f.readJson,
+	}
+}
+
+func (f *fsApi) object() (*goja.Object, error) {
+	return newApiObject(f.vm, f.functions())
+}
+
+// resolve returns the name of a file in the template directory. Paths are
+// relative to the template directory and must not leave it.
+func (f *fsApi) resolve(fn, name string) string {
+	clean := path.Clean(name)
+	if !fs.ValidPath(clean) {
+		panic(f.vm.NewTypeError("Fs.%s: outside of the template directory: %s", fn, name))
+	}
+	return clean
+}
+
+func (f *fsApi) read(name string) string {
+	content, err := fs.ReadFile(f.sc.fsys, f.resolve("read", name))
+	if err != nil {
+		panic(f.vm.NewGoError(err))
+	}
+	return string(content)
+}
+
+func (f *fsApi) exists(name string) bool {
+	_, err := fs.Stat(f.sc.fsys, f.resolve("exists", name))
+	return err == nil
+}
+
+func (f *fsApi) readJson(name string) goja.Value {
+	content, err := fs.ReadFile(f.sc.fsys, f.resolve("readJson", name))
+	if err != nil {
+		panic(f.vm.NewGoError(err))
+	}
+
+	var value any
+	err = json.Unmarshal(content, &value)
+	if err != nil {
+		panic(f.vm.NewGoError(fmt.Errorf("%s: %w", name, err)))
+	}
+	return f.vm.ToValue(value)
+}
+
+// schemaApi is the Schema object of the hooks runtime. It changes the table
+// list of the generation, which is only allowed until rendering starts.
+type schemaApi struct {
+	vm     *goja.Runtime
+	ctx    *genContext
+	locked bool
+}
+
+func (s *schemaApi) functions() map[string]any {
+	return map[string]any{
+		"tables":     s.tables,
+		"table":      s.table,
+		"addTable":   s.addTable,
+		"dropTable":  s.dropTable,
+		"addColumn":  s.addColumn,
+		"dropColumn": s.dropColumn,
+	}
+}
+
+func (s *schemaApi) object() (*goja.Object, error) {
+	return newApiObject(s.vm, s.functions())
+}
+
+func (s *schemaApi) tables() []*common.TableSchema {
+	return s.ctx.Tables
+}
+
+func (s *schemaApi) table(name string) *common.TableSchema {
+	for _, table := range s.ctx.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// addTable adds a virtual table, given as an object with the fields of
+// TableSchema, or replaces the table with the same name.
+func (s *schemaApi) addTable(value goja.Value) *common.TableSchema {
+	s.checkLocked("addTable")
+
+	table := new(common.TableSchema)
+	err := s.vm.ExportTo(value, table)
+	if err != nil {
+		panic(s.vm.NewTypeError("Schema.addTable: %s", err))
+	}
+	if table.Name == "" {
+		panic(s.vm.NewTypeError("Schema.addTable: table name is required"))
+	}
+	if table.Columns == nil {
+		table.Columns = []*common.ColumnSchema{}
+	}
+	table.IsVirtual = true
+	for _, column := range table.Columns {
+		column.IsVirtual = true
+	}
+
+	s.dropTable(table.Name)
+	s.ctx.Tables = append(s.ctx.Tables, table)
+	return table
+}
+
+func (s *schemaApi) dropTable(name string) bool {
+	s.checkLocked("dropTable")
+
+	n := len(s.ctx.Tables)
+	s.ctx.Tables = slices.DeleteFunc(s.ctx.Tables, func(t *common.TableSchema) bool {
+		return t.Name == name
+	})
+	return len(s.ctx.Tables) < n
+}
+
+// addColumn adds a computed column, given as an object with the fields of
+// ColumnSchema, to the table.
+func (s *schemaApi) addColumn(tableName string, value goja.Value) *common.ColumnSchema {
+	s.checkLocked("addColumn")
+
+	table := s.table(tableName)
+	if table == nil {
+		panic(s.vm.NewTypeError("Schema.addColumn: unknown table: %s", tableName))
+	}
+
+	column := new(common.ColumnSchema)
+	err := s.vm.ExportTo(value, column)
+	if err != nil {
+		panic(s.vm.NewTypeError("Schema.addColumn: %s", err))
+	}
+	if column.Name == "" {
+		panic(s.vm.NewTypeError("Schema.addColumn: column name is required"))
+	}
+	if column.DataType == "" {
+		column.DataType = common.DataTypeAny
+	}
+	column.IsVirtual = true
+
+	table.Columns = append(table.Columns, column)
+	return column
+}
+
+func (s *schemaApi) dropColumn(tableName, columnName string) bool {
+	s.checkLocked("dropColumn")
+
+	table := s.table(tableName)
+	if table == nil {
+		return false
+	}
+
+	n := len(table.Columns)
+	table.Columns = slices.DeleteFunc(table.Columns, func(c *common.ColumnSchema) bool {
+		return c.Name == columnName
+	})
+	return len(table.Columns) < n
+}
+
+func (s *schemaApi) checkLocked(name string) {
+	if s.locked {
+		panic(s.vm.NewTypeError(fmt.Sprintf("Schema.%s: the schema can only be changed before rendering starts", name)))
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOutputEmitPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"docs/a.md", "docs/a.md"},
+		{"./docs/../b.md", "b.md"},
+		{"../x.txt", ""},
+		{"docs/../../x.txt", ""},
+		{"/tmp/x.txt", ""},
+		{".", ""},
+	}
+
+	for _, tt := range tests {
+		fsys := fstest.MapFS{
+			"main.js": {Data: []byte("Output.emit(" + jsString(tt.path) + ", 'e.tmpl');\n")},
+		}
+		sc, err := newScriptContext(fsys, ScriptProps{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		emits, err := sc.run([]string{"main.js"}, "Model", map[string]any{})
+		if tt.want == "" {
+			var scriptErr *ScriptError
+			if !errors.As(err, &scriptErr) || !strings.Contains(scriptErr.Message, "outside of the output directory") {
+				t.Errorf("emit(%q) error = %v, want outside of the output directory", tt.path, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("emit(%q) error = %v", tt.path, err)
+			continue
+		}
+		if len(emits) != 1 || emits[0].Path != tt.want {
+			t.Errorf("emit(%q) = %v, want path %q", tt.path, emits, tt.want)
+		}
+	}
+}
+
+func jsString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
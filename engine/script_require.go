@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/parser"
+)
+
+const moduleWrapperPrefix = "(function(exports, require, module, __filename, __dirname) {"
+const moduleWrapperSuffix = "\n})"
+
+// moduleLoader implements a CommonJS style require() for one runtime. Module
+// ids starting with "./" or "../" are resolved relative to the requiring
+// module, other ids and the ids required by top-level scripts relative to the
+// template directory. Modules are evaluated once per runtime.
+type moduleLoader struct {
+	sc      *scriptContext
+	vm      *goja.Runtime
+	modules map[string]*goja.Object
+}
+
+func newModuleLoader(sc *scriptContext, vm *goja.Runtime) *moduleLoader {
+	return &moduleLoader{
+		sc:      sc,
+		vm:      vm,
+		modules: map[string]*goja.Object{},
+	}
+}
+
+// requireFunc returns the require function of a module in the directory dir.
+func (l *moduleLoader) requireFunc(dir string) func(id string) goja.Value {
+	return func(id string) goja.Value {
+		module, err := l.require(dir, id)
+		switch err.(type) {
+		case *goja.Exception, *goja.InterruptedError, *goja.StackOverflowError:
+			// Rethrown as is to keep the location within the module, and to
+			// keep interrupts uncatchable.
+			panic(err)
+		}
+		if err != nil {
+			panic(l.vm.NewGoError(err))
+		}
+		return module.Get("exports")
+	}
+}
+
+func (l *moduleLoader) require(dir, id string) (*goja.Object, error) {
+	name, err := l.resolve(dir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if module, ok := l.modules[name]; ok {
+		return module, nil
+	}
+
+	module := l.vm.NewObject()
+	exports := l.vm.NewObject()
+	err = module.Set("exports", exports)
+	if err != nil {
+		return nil, err
+	}
+	err = module.Set("id", name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Registered before evaluation, so that cyclic requires get the exports
+	// populated so far, as in Node.js.
+	l.modules[name] = module
+
+	if path.Ext(name) == ".json" {
+		err = l.loadJson(name, module)
+	} else {
+		err = l.loadScript(name, module, exports)
+	}
+	if err != nil {
+		delete(l.modules, name)
+		return nil, err
+	}
+	return module, nil
+}
+
+func (l *moduleLoader) loadJson(name string, module *goja.Object) error {
+	content, err := fs.ReadFile(l.sc.fsys, name)
+	if err != nil {
+		return err
+	}
+
+	var value any
+	err = json.Unmarshal(content, &value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return module.Set("exports", l.vm.ToValue(value))
+}
+
+func (l *moduleLoader) loadScript(name string, module, exports *goja.Object) error {
+	program, err := l.sc.compileModule(name)
+	if err != nil {
+		return err
+	}
+
+	value, err := l.vm.RunProgram(program)
+	if err != nil {
+		return err
+	}
+
+	fn, ok := goja.AssertFunction(value)
+	if !ok {
+		return fmt.Errorf("module %s is not a function", name)
+	}
+
+	dir := path.Dir(name)
+	_, err = fn(exports, exports, l.vm.ToValue(l.requireFunc(dir)), module,
+		l.vm.ToValue(name), l.vm.ToValue(dir))
+	return err
+}
+
+// resolve returns the path of the module relative to the template directory,
+// trying the id as is, with a ".js" or ".json" extension and as a directory
+// with an "index.js".
+func (l *moduleLoader) resolve(dir, id string) (string, error) {
+	name := id
+	if strings.HasPrefix(id, "./") || strings.HasPrefix(id, "../") {
+		name = path.Join(dir, id)
+	}
+	name = path.Clean(name)
+
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("cannot require %s: outside of the template directory", id)
+	}
+
+	candidates := []string{name, name + ".js", name + ".json", path.Join(name, "index.js")}
+	for _, candidate := range candidates {
+		info, err := fs.Stat(l.sc.fsys, candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find module %s", id)
+}
+
+func (sc *scriptContext) compileModule(name string) (*goja.Program, error) {
+	if program, ok := sc.modules[name]; ok {
+		return program, nil
+	}
+
+	content, err := fs.ReadFile(sc.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The wrapper is kept on the first line so that line numbers are unchanged.
+	source := moduleWrapperPrefix + string(content) + moduleWrapperSuffix
+	ast, err := parser.ParseFile(nil, name, source, 0)
+	if err == nil {
+		var program *goja.Program
+		program, err = goja.CompileAST(ast, false)
+		if err == nil {
+			sc.modules[name] = program
+			return program, nil
+		}
+	}
+
+	e := sc.newScriptError(name, err)
+	if e.Line == 1 {
+		e.Column -= len(moduleWrapperPrefix)
+	}
+	return nil, e
+}
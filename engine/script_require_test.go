@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRequireModuleCompileError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.js":    {Data: []byte("require('./lib/bad');\n")},
+		"lib/bad.js": {Data: []byte("let a;\nlet a;\n")},
+	}
+	sc, err := newScriptContext(fsys, ScriptProps{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sc.compileModule("lib/bad.js")
+	var scriptErr *ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("compileModule() error = %v, want a *ScriptError", err)
+	}
+	if scriptErr.File != "lib/bad.js" || scriptErr.Line != 2 {
+		t.Errorf("compileModule() error at %s:%d, want lib/bad.js:2", scriptErr.File, scriptErr.Line)
+	}
+
+	_, err = sc.run([]string{"main.js"}, "Data", map[string]any{})
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("run() error = %v, want a *ScriptError", err)
+	}
+	if scriptErr.File != "lib/bad.js" {
+		t.Errorf("run() error in %s, want lib/bad.js", scriptErr.File)
+	}
+}
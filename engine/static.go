@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+
+	"crudify/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const templateFileExt = ".tmpl"
+
+// expandDirectories turns the files of the manifest's template directories
+// into global or entity templates.
+func (g *Generator) expandDirectories(manifest *ManifestModel) error {
+	for i := range manifest.Directories {
+		dir := &manifest.Directories[i]
+		root := path.Clean(dir.Dir)
+		err := fs.WalkDir(g.tmplFS, root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			rel := relPath(root, p)
+
+			props := TemplateProps{
+				File:        path.Join(root, rel),
+				Script:      dir.Script,
+				Output:      joinOutputPath(dir.Output, strings.TrimSuffix(rel, templateFileExt)),
+				PostProcess: dir.PostProcess,
+				verbatim:    !strings.HasSuffix(rel, templateFileExt),
+				directory:   dir,
+			}
+
+			if dir.Entity {
+				manifest.EntityTemplates = append(manifest.EntityTemplates, props)
+			} else {
+				manifest.GlobalTemplates = append(manifest.GlobalTemplates, props)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Generator) copyStaticFiles(ctx *genContext) error {
+	if len(ctx.Manifest.Static) <= 0 {
+		return nil
+	}
+
+	logrus.Info("Copying static files")
+
+	data := &GlobalTemplateData{
+		Vars:   utils.MergeVariables(ctx.Vars),
+		Tables: ctx.Tables,
+	}
+
+	for _, props := range ctx.Manifest.Static {
+		output, err := resolveGlobalOutputPath(props.Output, data, ctx.Funcs)
+		if err != nil {
+			return err
+		}
+
+		pattern := path.Clean(props.Files)
+		base := staticBaseDir(pattern)
+
+		err = fs.WalkDir(g.tmplFS, base, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !matchPath(pattern, p) {
+				return nil
+			}
+
+			content, err := fs.ReadFile(g.tmplFS, p)
+			if err != nil {
+				return err
+			}
+
+			outputPath := relPath(base, p)
+			logrus.Debugf("Copying static file: %s", p)
+			return g.writeFile(ctx, nil, joinOutputPath(output, outputPath), content)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// staticBaseDir returns the leading directories of the glob without any
+// wildcard, "." if the first one has a wildcard, or the directory of the path
+// if the glob has no wildcard at all.
+func staticBaseDir(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "*?[") {
+			if i == 0 {
+				return "."
+			}
+			return strings.Join(segments[:i], "/")
+		}
+	}
+	return path.Dir(pattern)
+}
+
+// relPath returns the slash separated path p relative to its parent dir.
+func relPath(dir, p string) string {
+	if dir == "." {
+		return p
+	}
+	return strings.TrimPrefix(p, dir+"/")
+}
+
+func joinOutputPath(dir, name string) string {
+	if dir == "" || dir == "." {
+		return name
+	}
+	return strings.TrimSuffix(dir, "/") + "/" + name
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCopyStaticFiles(t *testing.T) {
+	files := fstest.MapFS{
+		"manifest.yaml": {Data: []byte(`
+static:
+  - files: pom.xml
+  - files: "*.yaml"
+    output: yaml
+  - files: "assets/**/*.png"
+    output: public
+`)},
+		"pom.xml":                {Data: []byte("root")},
+		"sub/pom.xml":            {Data: []byte("sub")},
+		"templates/pom.xml":      {Data: []byte("templates")},
+		"app.yaml":               {Data: []byte("app")},
+		"sub/other.yaml":         {Data: []byte("other")},
+		"assets/logo.png":        {Data: []byte("logo")},
+		"assets/icons/add.png":   {Data: []byte("add")},
+		"assets/icons/add.svg":   {Data: []byte("svg")},
+		"templates/assets/x.png": {Data: []byte("x")},
+	}
+
+	g, ctx := newTestGenerator(t, files, 1)
+	err := g.render(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, file := range ctx.Files {
+		got = append(got, file.Path)
+	}
+	want := []string{
+		"pom.xml",
+		"yaml/app.yaml",
+		"yaml/manifest.yaml",
+		"public/icons/add.png",
+		"public/logo.png",
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("copied files = %v, want %v", got, want)
+	}
+	if content := readOutput(t, g, "pom.xml"); content != "root" {
+		t.Errorf("pom.xml = %q, want %q", content, "root")
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		glob    bool
+		path    bool
+	}{
+		{"pom.xml", "pom.xml", true, true},
+		{"pom.xml", "sub/pom.xml", true, false},
+		{"*.go", "a/b.go", true, false},
+		{"*.go", "b.go", true, true},
+		{"**/*.go", "a/b/c.go", true, true},
+		{"**/*.go", "c.go", true, true},
+		{"dao/*.yaml", "dao/a.yaml", true, true},
+		{"dao/*.yaml", "x/dao/a.yaml", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.glob {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.glob)
+		}
+		if got := matchPath(tt.pattern, tt.name); got != tt.path {
+			t.Errorf("matchPath(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.path)
+		}
+	}
+}
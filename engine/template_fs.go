@@ -0,0 +1,252 @@
+package engine
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"crudify/packs"
+	"gopkg.in/yaml.v3"
+)
+
+// BuiltinPackPrefix selects a template pack embedded in the binary as the
+// template source, as in "builtin:go-sqlx".
+const BuiltinPackPrefix = "builtin:"
+
+// PackInfoFileName is the file of the output directory that records the
+// template pack of the last full generation.
+const PackInfoFileName = ".crudify-pack.yaml"
+
+// PackInfo identifies the template pack of a generation, it is available to
+// templates and scripts as the Pack variable. Ref is the requested ref of a
+// git repository, Version the resolved commit of a git repository or the
+// SHA-256 digest of an archive. Extends lists the packs the pack is based on,
+// the nearest first.
+type PackInfo struct {
+	Source  string     `yaml:"source"`
+	Ref     string     `yaml:"ref,omitempty"`
+	Version string     `yaml:"version,omitempty"`
+	Extends []PackInfo `yaml:"extends,omitempty"`
+}
+
+// templatePack is an opened template source. Dir is the host directory of the
+// pack or empty if the pack is not a directory and can not be watched. Base is
+// the host directory relative sources of the manifest are resolved against.
+type templatePack struct {
+	FS   fs.FS
+	Dir  string
+	Base string
+	Info PackInfo
+}
+
+// openTemplatePack opens a template source, which is a template directory, a
+// builtin pack, a zip or tar.gz archive, or a git repository with an optional
+// ref, as in "./packs.git#v2.1".
+func openTemplatePack(source string) (*templatePack, error) {
+	if name, ok := strings.CutPrefix(source, BuiltinPackPrefix); ok {
+		fsys, err := packs.Builtin(name)
+		if err != nil {
+			return nil, err
+		}
+		return &templatePack{FS: fsys, Info: PackInfo{Source: source}}, nil
+	}
+
+	repo, ref, hasRef := strings.Cut(source, "#")
+	if _, err := os.Stat(source); err == nil || !hasRef {
+		repo, ref = source, ""
+	}
+
+	info, err := os.Stat(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case ref != "" || (info.IsDir() && strings.HasSuffix(path.Clean(repo), ".git")):
+		return openGitPack(repo, ref)
+	case info.IsDir():
+		return &templatePack{FS: os.DirFS(repo), Dir: repo, Base: repo, Info: PackInfo{Source: source}}, nil
+	case strings.HasSuffix(repo, ".zip"), strings.HasSuffix(repo, ".tar.gz"), strings.HasSuffix(repo, ".tgz"):
+		return openArchivePack(repo)
+	default:
+		return nil, fmt.Errorf("unsupported template source: %s, expected a directory, a .zip or .tar.gz archive or a git repository", source)
+	}
+}
+
+func openArchivePack(filename string) (*templatePack, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var fsys fs.FS
+	if strings.HasSuffix(filename, ".zip") {
+		fsys, err = zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	} else {
+		fsys, err = readTarGz(bytes.NewReader(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	fsys, err = packRoot(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	digest := sha256.Sum256(content)
+	return &templatePack{
+		FS:   fsys,
+		Base: filepath.Dir(filename),
+		Info: PackInfo{
+			Source:  filename,
+			Version: "sha256:" + hex.EncodeToString(digest[:]),
+		},
+	}, nil
+}
+
+// openGitPack reads the tree of the ref, HEAD by default, of a local git
+// repository. Uncommitted changes of a work tree are not part of the pack.
+func openGitPack(repo, ref string) (*templatePack, error) {
+	rev := ref
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	out, err := runGit(repo, "rev-parse", "--verify", "--end-of-options", rev+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("%s#%s: %w", repo, rev, err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	out, err = runGit(repo, "archive", "--format=tar", commit)
+	if err != nil {
+		return nil, fmt.Errorf("%s#%s: %w", repo, rev, err)
+	}
+
+	fsys, err := readTar(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", repo, err)
+	}
+
+	fsys, err = packRoot(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s#%s: %w", repo, rev, err)
+	}
+
+	return &templatePack{
+		FS:   fsys,
+		Base: filepath.Dir(filepath.Clean(repo)),
+		Info: PackInfo{
+			Source:  repo,
+			Ref:     ref,
+			Version: commit,
+		},
+	}, nil
+}
+
+func runGit(repo string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func readTarGz(r io.Reader) (fs.FS, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return readTar(gz)
+}
+
+// readTar reads the regular files of a tar archive into memory, other entries
+// are skipped.
+func readTar(r io.Reader) (fs.FS, error) {
+	fsys := memFS{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return fsys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if !fs.ValidPath(name) {
+			return nil, fmt.Errorf("invalid file name: %s", header.Name)
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		fsys[name] = &memFile{
+			data:    content,
+			mode:    header.FileInfo().Mode(),
+			modTime: header.ModTime,
+		}
+	}
+}
+
+// packRoot returns the directory of the pack that holds the manifest, which is
+// the root of the files or their only top-level directory, as archives of a
+// directory usually contain the directory itself.
+func packRoot(fsys fs.FS) (fs.FS, error) {
+	if _, err := fs.Stat(fsys, ManifestFileName); err == nil {
+		return fsys, nil
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		sub, err := fs.Sub(fsys, entries[0].Name())
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fs.Stat(sub, ManifestFileName); err == nil {
+			return sub, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s found", ManifestFileName)
+}
+
+// writePackInfo records the template pack in the output directory.
+func (g *Generator) writePackInfo() error {
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by crudify, the template pack of the last generation.\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err := encoder.Encode(map[string]PackInfo{"pack": g.packInfo()})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(g.outputDir, 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(g.outputDir, PackInfoFileName), buf.Bytes(), 0o644)
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadTar(t *testing.T) {
+	files := map[string]string{
+		"pack/manifest.yaml":         "entity-templates: []\n",
+		"pack/templates/entity.tmpl": "{{.Table.Name}}",
+		"pack/templates/sub/a.tmpl":  "a",
+		"pack/scripts/entity.js":     "",
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := tw.WriteHeader(&tar.Header{Name: "pack/", Typeflag: tar.TypeDir, Mode: 0o755})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		err = tw.WriteHeader(&tar.Header{Name: "./" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))})
+		if err == nil {
+			_, err = tw.Write([]byte(content))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys, err := readTar(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fstest.TestFS(fsys, "pack/manifest.yaml", "pack/templates/entity.tmpl", "pack/templates/sub/a.tmpl", "pack/scripts/entity.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := packRoot(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := fs.ReadFile(root, "templates/entity.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != files["pack/templates/entity.tmpl"] {
+		t.Errorf("templates/entity.tmpl = %q, want %q", content, files["pack/templates/entity.tmpl"])
+	}
+
+	_, err = fs.Stat(root, "templates/missing.tmpl")
+	if err == nil {
+		t.Error("missing file found")
+	}
+}
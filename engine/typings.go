@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"crudify/schema/common"
+	"github.com/dop251/goja"
+)
+
+const TypeScriptLang = "ts"
+
+var (
+	gojaValueType = reflect.TypeFor[goja.Value]()
+	errorType     = reflect.TypeFor[error]()
+)
+
+// WriteTypes writes the declarations of the script API for the language. The
+// declarations are generated from the Go types exposed to scripts, so they
+// always match the running version of crudify.
+func WriteTypes(w io.Writer, lang string) error {
+	switch lang {
+	case TypeScriptLang:
+		return writeTypeScriptTypes(w)
+	default:
+		return fmt.Errorf("unsupported types language: %s", lang)
+	}
+}
+
+// tsWriter converts Go types to TypeScript. Named structs become interfaces,
+// named basic types become type aliases, both are declared once.
+type tsWriter struct {
+	b        strings.Builder
+	declared map[reflect.Type]bool
+	pending  []reflect.Type
+}
+
+func writeTypeScriptTypes(w io.Writer) error {
+	tw := &tsWriter{declared: map[reflect.Type]bool{}}
+	b := &tw.b
+
+	b.WriteString("// Code generated by crudify types; DO NOT EDIT.\n\n")
+
+	b.WriteString("/** The model of the script, EntityTemplateData in entity scripts and GlobalTemplateData in global scripts. */\n")
+	fmt.Fprintf(b, "declare const Model: %s | %s;\n", tw.typeName(reflect.TypeFor[EntityTemplateData]()),
+		tw.typeName(reflect.TypeFor[GlobalTemplateData]()))
+	fmt.Fprintf(b, "declare const F: %s;\n", tw.typeName(reflect.TypeFor[JsFunctions]()))
+	fmt.Fprintf(b, "declare const Utils: %s;\n", tw.typeName(reflect.TypeFor[JsFunctions]()))
+	b.WriteString("declare const Output: OutputApi;\n")
+	b.WriteString("declare const Fs: FsApi;\n")
+	b.WriteString("/** Only available in the hooks module. */\n")
+	b.WriteString("declare const Schema: SchemaApi;\n")
+	b.WriteString("declare function require(id: string): any;\n")
+	b.WriteString("declare const module: { exports: any; id: string };\n")
+	b.WriteString("declare const exports: any;\n")
+	b.WriteString("declare const __filename: string;\n")
+	b.WriteString("declare const __dirname: string;\n")
+
+	tw.writeApi("OutputApi", new(outputApi).functions())
+	tw.writeApi("FsApi", new(fsApi).functions())
+	tw.writeApi("SchemaApi", new(schemaApi).functions())
+
+	b.WriteString("\n/** The functions a hooks module may export. */\n")
+	b.WriteString("interface Hooks {\n")
+	hooks := []struct {
+		name string
+		arg  reflect.Type
+	}{
+		{HookBeforeAll, reflect.TypeFor[HookContext]()},
+		{HookBeforeTable, reflect.TypeFor[common.TableSchema]()},
+		{HookAfterTable, reflect.TypeFor[common.TableSchema]()},
+		{HookAfterAll, reflect.TypeFor[HookReport]()},
+	}
+	for _, hook := range hooks {
+		fmt.Fprintf(b, "  %s?(arg0: %s): void;\n", hook.name, tw.typeName(hook.arg))
+	}
+	b.WriteString("}\n")
+
+	for len(tw.pending) > 0 {
+		t := tw.pending[0]
+		tw.pending = tw.pending[1:]
+		tw.writeDeclaration(t)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeApi declares an interface for the function table of a script API.
+func (tw *tsWriter) writeApi(name string, fns map[string]any) {
+	names := make([]string, 0, len(fns))
+	for fnName := range fns {
+		names = append(names, fnName)
+	}
+	slices.Sort(names)
+
+	fmt.Fprintf(&tw.b, "\ninterface %s {\n", name)
+	for _, fnName := range names {
+		fmt.Fprintf(&tw.b, "  %s%s;\n", fnName, tw.signature(reflect.TypeOf(fns[fnName]), false))
+	}
+	tw.b.WriteString("}\n")
+}
+
+func (tw *tsWriter) writeDeclaration(t reflect.Type) {
+	if t.Kind() != reflect.Struct {
+		fmt.Fprintf(&tw.b, "\ntype %s = %s;\n", t.Name(), tw.basicType(t))
+		return
+	}
+
+	fmt.Fprintf(&tw.b, "\ninterface %s {\n", t.Name())
+	tw.writeFields(t)
+
+	// Methods are called on pointers to the exported values.
+	pt := reflect.PointerTo(t)
+	for i := 0; i < pt.NumMethod(); i++ {
+		method := pt.Method(i)
+		fmt.Fprintf(&tw.b, "  %s%s;\n", method.Name, tw.signature(method.Type, true))
+	}
+	tw.b.WriteString("}\n")
+}
+
+// writeFields writes the exported fields, the fields of embedded structs are
+// promoted as in Go.
+func (tw *tsWriter) writeFields(t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && deref(field.Type).Kind() == reflect.Struct {
+			tw.writeFields(deref(field.Type))
+			continue
+		}
+		fmt.Fprintf(&tw.b, "  %s: %s;\n", field.Name, tw.typeName(field.Type))
+	}
+}
+
+// signature returns the parameters and the result of a function or method
+// type. A trailing error result becomes an exception in scripts and is left
+// out, several results are returned as an array.
+func (tw *tsWriter) signature(t reflect.Type, method bool) string {
+	start := 0
+	if method {
+		start = 1
+	}
+
+	params := []string{}
+	for i := start; i < t.NumIn(); i++ {
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			params = append(params, fmt.Sprintf("...arg%d: %s", i-start, tw.typeName(t.In(i))))
+			continue
+		}
+		params = append(params, fmt.Sprintf("arg%d: %s", i-start, tw.typeName(t.In(i))))
+	}
+
+	results := []reflect.Type{}
+	for i := 0; i < t.NumOut(); i++ {
+		results = append(results, t.Out(i))
+	}
+	if len(results) > 0 && results[len(results)-1] == errorType {
+		results = results[:len(results)-1]
+	}
+
+	result := "void"
+	if len(results) == 1 {
+		result = tw.typeName(results[0])
+	} else if len(results) > 1 {
+		result = "any[]"
+	}
+
+	return fmt.Sprintf("(%s): %s", strings.Join(params, ", "), result)
+}
+
+// typeName returns the TypeScript type of a Go type, queueing the declaration
+// of named types.
+func (tw *tsWriter) typeName(t reflect.Type) string {
+	t = deref(t)
+	if t == gojaValueType || t.Kind() == reflect.Interface {
+		return "any"
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return "any"
+		}
+		tw.declare(t)
+		return t.Name()
+	case reflect.Slice, reflect.Array:
+		elem := tw.typeName(t.Elem())
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case reflect.Map:
+		return fmt.Sprintf("Record<%s, %s>", tw.typeName(t.Key()), tw.typeName(t.Elem()))
+	case reflect.Func:
+		return tw.signature(t, false)
+	}
+
+	if t.Name() != "" && t.PkgPath() != "" {
+		tw.declare(t)
+		return t.Name()
+	}
+	return tw.basicType(t)
+}
+
+func (tw *tsWriter) basicType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "any"
+	}
+}
+
+func (tw *tsWriter) declare(t reflect.Type) {
+	if !tw.declared[t] {
+		tw.declared[t] = true
+		tw.pending = append(tw.pending, t)
+	}
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
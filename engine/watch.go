@@ -0,0 +1,343 @@
+package engine
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+const watchDebounce = 200 * time.Millisecond
+
+// Watch renders all templates once and then keeps re-rendering the templates
+// affected by changes to the template directory, the manifest, the scripts or
+// the config file. The database schema is read once and cached, entering "r"
+// on stdin re-reads it.
+func (g *Generator) Watch() error {
+	ctx := new(genContext)
+
+	err := g.readManifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = g.readDbSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = g.render(ctx, nil)
+	if err != nil {
+		logrus.Error(err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := watcher.Close(); e != nil {
+			logrus.Error(e)
+		}
+	}()
+
+	err = g.addWatchPaths(watcher)
+	if err != nil {
+		return err
+	}
+
+	commands := readWatchCommands()
+	logrus.Info(`Watching for changes, enter "r" to re-read the database schema, Ctrl+C to quit`)
+
+	changed := map[string]bool{}
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, e := os.Stat(event.Name); e == nil && info.IsDir() {
+					g.addWatchDir(watcher, event.Name)
+				}
+			}
+			logrus.Debugf("Changed: %s", event.Name)
+			changed[event.Name] = true
+			debounce = time.After(watchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logrus.Error(err)
+
+		case <-debounce:
+			debounce = nil
+			err = g.applyChanges(ctx, changed)
+			if err != nil {
+				logrus.Error(err)
+			}
+			changed = map[string]bool{}
+
+		case cmd, ok := <-commands:
+			if !ok {
+				commands = nil
+				continue
+			}
+			if cmd != "r" {
+				continue
+			}
+			err = g.readDbSchema(ctx)
+			if err == nil {
+				err = g.render(ctx, nil)
+			}
+			if err != nil {
+				logrus.Error(err)
+			}
+		}
+	}
+}
+
+func (g *Generator) addWatchPaths(watcher *fsnotify.Watcher) error {
+	// Packs that are not a directory are read once, only the directories of
+	// the pack and the packs it extends are watched.
+	for _, dir := range g.packDirs() {
+		err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return watcher.Add(p)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Editors often replace files instead of writing them, so the directory of
+	// the config file is watched rather than the file itself.
+	return watcher.Add(filepath.Dir(g.configFile))
+}
+
+// packDirs returns the host directories of the pack and the packs it extends.
+func (g *Generator) packDirs() []string {
+	dirs := []string{}
+	if g.tmplDir != "" {
+		dirs = append(dirs, g.tmplDir)
+	}
+	for _, base := range g.bases {
+		if base.Dir != "" {
+			dirs = append(dirs, base.Dir)
+		}
+	}
+	return dirs
+}
+
+// packFile returns the name of a host file within the files of the packs.
+func (g *Generator) packFile(name string) (string, bool) {
+	for _, dir := range g.packDirs() {
+		rel, err := filepath.Rel(dir, name)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel), true
+		}
+	}
+	return "", false
+}
+
+func (g *Generator) addWatchDir(watcher *fsnotify.Watcher, dir string) {
+	err := watcher.Add(dir)
+	if err != nil {
+		logrus.Error(err)
+	}
+}
+
+func (g *Generator) applyChanges(ctx *genContext, changed map[string]bool) error {
+	reloadConfig := false
+	reloadManifest := false
+	files := map[string]bool{}
+
+	for name := range changed {
+		if sameFile(name, g.configFile) {
+			reloadConfig = true
+			continue
+		}
+		rel, ok := g.packFile(name)
+		if !ok {
+			continue
+		}
+		if rel == ManifestFileName || isTreeFile(ctx.Manifest, rel) {
+			reloadManifest = true
+			continue
+		}
+		files[rel] = true
+	}
+
+	if reloadConfig {
+		logrus.Infof("Config changed: %s", g.configFile)
+		config, err := ReadConfig(g.configFile)
+		if err != nil {
+			return err
+		}
+		dbChanged := config.Database != g.config.Database
+		g.config = config
+		if dbChanged {
+			err = g.readDbSchema(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if reloadManifest {
+		logrus.Info("Manifest changed")
+		err := g.readManifest(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderAll := reloadConfig || reloadManifest
+	for file := range files {
+		if !renderAll && affectsAll(ctx.Manifest, file) {
+			logrus.Infof("Pack file changed: %s", file)
+			renderAll = true
+		}
+	}
+	if renderAll {
+		return g.render(ctx, nil)
+	}
+
+	filter := affectedTemplates(ctx.Manifest, files)
+	if filter == nil {
+		return nil
+	}
+	return g.render(ctx, filter)
+}
+
+// affectsAll reports whether a changed pack file may affect every template:
+// the hooks module and the modules it may require, and any other file than a
+// template or a script module, such as the data files read through Fs. Hidden
+// and backup files of editors are ignored.
+func affectsAll(manifest *ManifestModel, file string) bool {
+	base := path.Base(file)
+	if strings.HasPrefix(base, ".") || strings.HasSuffix(base, "~") {
+		return false
+	}
+
+	ext := path.Ext(file)
+	if ext == ".js" || ext == ".json" {
+		return manifest.Hooks != ""
+	}
+
+	for _, templates := range [][]TemplateProps{manifest.GlobalTemplates, manifest.EntityTemplates} {
+		for _, props := range templates {
+			if path.Clean(props.File) == file {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// affectedTemplates returns a filter selecting the templates that use any of
+// the changed files, or nil if no template is affected.
+func affectedTemplates(manifest *ManifestModel, files map[string]bool) templateFilter {
+	selected := map[*TemplateProps]bool{}
+
+	// Modules may be required by any script, so a changed module affects all
+	// templates that run scripts.
+	modulesChanged := false
+	for file := range files {
+		ext := path.Ext(file)
+		if ext == ".js" || ext == ".json" {
+			modulesChanged = true
+		}
+	}
+
+	// Template functions may be called by any template.
+	funcsChanged := modulesChanged && len(manifest.TemplateFunctions) > 0
+
+	selectTemplates := func(scripts []string, templates []TemplateProps) {
+		scriptsChanged := funcsChanged || (modulesChanged && len(scripts) > 0)
+		for _, script := range scripts {
+			if files[path.Clean(script)] {
+				scriptsChanged = true
+			}
+		}
+		for i := range templates {
+			props := &templates[i]
+			if scriptsChanged || files[path.Clean(props.File)] ||
+				(props.Script != "" && (modulesChanged || files[path.Clean(props.Script)])) {
+				selected[props] = true
+			}
+		}
+	}
+
+	selectTemplates(manifest.GlobalScripts, manifest.GlobalTemplates)
+	selectTemplates(manifest.EntityScripts, manifest.EntityTemplates)
+
+	if len(selected) <= 0 {
+		return nil
+	}
+
+	for props := range selected {
+		logrus.Infof("Template affected: %s", props.File)
+	}
+
+	return func(props *TemplateProps) bool {
+		return selected[props]
+	}
+}
+
+// isTreeFile reports whether the file belongs to a static glob or a template
+// directory, whose file lists have to be expanded again.
+func isTreeFile(manifest *ManifestModel, file string) bool {
+	for _, dir := range manifest.Directories {
+		if strings.HasPrefix(file, path.Clean(dir.Dir)+"/") {
+			return true
+		}
+	}
+	for _, static := range manifest.Static {
+		if matchPath(path.Clean(static.Files), file) {
+			return true
+		}
+	}
+	return false
+}
+
+func readWatchCommands() <-chan string {
+	commands := make(chan string)
+	go func() {
+		defer close(commands)
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			commands <- strings.ToLower(strings.TrimSpace(scanner.Text()))
+		}
+	}()
+	return commands
+}
+
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ai, bi)
+}
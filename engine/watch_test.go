@@ -0,0 +1,37 @@
+package engine
+
+import "testing"
+
+func TestAffectsAll(t *testing.T) {
+	manifest := &ManifestModel{
+		EntityScripts:   []string{"scripts/entity.js"},
+		EntityTemplates: []TemplateProps{{File: "templates/entity.tmpl"}},
+		GlobalTemplates: []TemplateProps{{File: "./templates/tables.tmpl"}},
+	}
+	withHooks := *manifest
+	withHooks.Hooks = "scripts/hooks.js"
+
+	tests := []struct {
+		manifest *ManifestModel
+		file     string
+		want     bool
+	}{
+		{manifest, "templates/entity.tmpl", false},
+		{manifest, "templates/tables.tmpl", false},
+		{manifest, "scripts/entity.js", false},
+		{manifest, "lib/data.json", false},
+		{manifest, "data/codes.csv", true},
+		{manifest, "templates/emitted.tmpl", true},
+		{manifest, "templates/.entity.tmpl.swp", false},
+		{manifest, "templates/entity.tmpl~", false},
+		{&withHooks, "scripts/hooks.js", true},
+		{&withHooks, "lib/naming.js", true},
+		{&withHooks, "templates/entity.tmpl", false},
+	}
+
+	for _, tt := range tests {
+		if got := affectsAll(tt.manifest, tt.file); got != tt.want {
+			t.Errorf("affectsAll(hooks %q, %q) = %v, want %v", tt.manifest.Hooks, tt.file, got, tt.want)
+		}
+	}
+}
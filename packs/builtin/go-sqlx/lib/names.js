@@ -0,0 +1,13 @@
+// Names shared by the global and the entity scripts.
+
+const quote = name => "`" + name + "`";
+
+exports.quote = quote;
+
+exports.typeName = table => F.ToPascalCaseInitialisms(F.ToSingular(table.Name));
+
+exports.repositoryName = table => exports.typeName(table) + "Repository";
+
+// columns returns the columns stored in the table, computed columns added by
+// scripts are only fields of the model.
+exports.columns = table => table.Columns.filter(column => !column.IsVirtual);
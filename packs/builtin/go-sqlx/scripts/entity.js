@@ -0,0 +1,30 @@
+const names = require("lib/names");
+const { quote } = names;
+
+const table = Model.Table;
+const columns = names.columns(table);
+const inserted = columns.filter(column => !column.IsAutoIncrement);
+const pk = table.PrimaryKeyColumn();
+
+Model.Vars.TypeName = names.typeName(table);
+Model.Vars.RepositoryName = names.repositoryName(table);
+Model.Vars.AutoIncrement = pk != null && pk.IsAutoIncrement;
+
+Model.Vars.SelectSql = `SELECT ${columns.map(c => quote(c.Name)).join(", ")} FROM ${quote(table.Name)}`;
+Model.Vars.InsertSql = `INSERT INTO ${quote(table.Name)} (${inserted.map(c => quote(c.Name)).join(", ")})` +
+  ` VALUES (${inserted.map(c => ":" + c.Name).join(", ")})`;
+
+if (pk) {
+  const updated = columns.filter(column => !column.IsPrimaryKey);
+  Model.Vars.PrimaryKey = {
+    Field: pk.SafeIdentifier("go"),
+    Param: pk.SafeIdentifier("go", "camel"),
+    Type: pk.GoType(),
+    Where: `${quote(pk.Name)} = ?`,
+  };
+  if (updated.length > 0) {
+    Model.Vars.UpdateSql = `UPDATE ${quote(table.Name)} SET ${updated.map(c => `${quote(c.Name)} = :${c.Name}`).join(", ")}` +
+      ` WHERE ${quote(pk.Name)} = :${pk.Name}`;
+  }
+  Model.Vars.DeleteSql = `DELETE FROM ${quote(table.Name)} WHERE ${quote(pk.Name)} = ?`;
+}
@@ -0,0 +1,7 @@
+const names = require("lib/names");
+
+Model.Vars.Repositories = Model.Tables.map(table => ({
+  Field: names.typeName(table),
+  Type: names.repositoryName(table),
+  Table: table.Name,
+}));
@@ -0,0 +1,19 @@
+const table = Model.Table;
+
+Model.Vars.ClassName = F.ToSingularPascalCase(table.Name);
+Model.Vars.Fields = table.Columns.map(column => {
+  const field = column.SafeIdentifier("java");
+  return {
+    Column: column.Name,
+    // Accessors are named after the field, so that a column like "class"
+    // gets getClass_() instead of overriding Object.getClass().
+    Name: field.charAt(0).toUpperCase() + field.slice(1),
+    Field: field,
+    Type: column.JavaType("boxed"),
+    Comment: column.Comment,
+    IsPrimaryKey: column.IsPrimaryKey,
+    IsAutoIncrement: column.IsAutoIncrement,
+    // Computed columns are not stored in the table.
+    IsVirtual: column.IsVirtual,
+  };
+});
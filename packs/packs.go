@@ -0,0 +1,67 @@
+// Package packs holds the template packs embedded in the crudify binary.
+package packs
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+//go:embed all:starter
+var starterFiles embed.FS
+
+//go:embed all:builtin
+var builtinFiles embed.FS
+
+// starterConfig is the sample config file written next to a starter pack.
+const starterConfig = "starter/config.yaml"
+
+// StarterLanguages returns the languages of the starter packs.
+func StarterLanguages() []string {
+	return packNames(starterFiles, "starter")
+}
+
+// Starter returns the files of the starter pack of the language, rooted at the
+// template directory.
+func Starter(lang string) (fs.FS, error) {
+	if !slices.Contains(StarterLanguages(), lang) {
+		return nil, fmt.Errorf("unknown starter language: %s, expected one of %s",
+			lang, strings.Join(StarterLanguages(), ", "))
+	}
+	return fs.Sub(starterFiles, "starter/"+lang)
+}
+
+// StarterConfig returns the content of the sample config file.
+func StarterConfig() ([]byte, error) {
+	return starterFiles.ReadFile(starterConfig)
+}
+
+// BuiltinNames returns the names of the builtin packs.
+func BuiltinNames() []string {
+	return packNames(builtinFiles, "builtin")
+}
+
+// Builtin returns the files of the builtin pack with the name, rooted at the
+// template directory.
+func Builtin(name string) (fs.FS, error) {
+	if !slices.Contains(BuiltinNames(), name) {
+		return nil, fmt.Errorf("unknown builtin pack: %s, expected one of %s",
+			name, strings.Join(BuiltinNames(), ", "))
+	}
+	return fs.Sub(builtinFiles, "builtin/"+name)
+}
+
+// packNames returns the names of the packs, which are the directories of dir.
+func packNames(files embed.FS, dir string) []string {
+	entries, _ := files.ReadDir(dir)
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+	return names
+}
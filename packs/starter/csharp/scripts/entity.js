@@ -0,0 +1,4 @@
+// Sample entity script. Model is the data of the entity template: the table,
+// the global variables and the entity variables.
+
+Model.Vars.ClassName = F.ToSingularPascalCase(Model.Table.Name);
@@ -0,0 +1,5 @@
+// Sample entity script. Model is the data of the entity template: the table,
+// the global variables and the entity variables.
+
+Model.Vars.TypeName = F.ToPascalCaseInitialisms(F.ToSingular(Model.Table.Name));
+Model.Vars.Receiver = Model.Vars.TypeName.charAt(0).toLowerCase();
@@ -0,0 +1,14 @@
+// Sample entity script. Model is the data of the entity template: the table,
+// the global variables and the entity variables.
+
+Model.Vars.ClassName = F.ToSingularPascalCase(Model.Table.Name);
+Model.Vars.Accessors = Model.Table.Columns.map(column => {
+  const field = column.SafeIdentifier("java");
+  return {
+    // Accessors are named after the field, so that a column like "class"
+    // gets getClass_() instead of overriding Object.getClass().
+    Name: field.charAt(0).toUpperCase() + field.slice(1),
+    Field: field,
+    Type: column.JavaType(),
+  };
+});
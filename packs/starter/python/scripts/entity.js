@@ -0,0 +1,5 @@
+// Sample entity script. Model is the data of the entity template: the table,
+// the global variables and the entity variables.
+
+Model.Vars.ClassName = F.ToSingularPascalCase(Model.Table.Name);
+Model.Vars.ModuleName = F.ToSingularSnakeCase(Model.Table.Name);
@@ -0,0 +1,5 @@
+// Sample entity script. Model is the data of the entity template: the table,
+// the global variables and the entity variables.
+
+Model.Vars.InterfaceName = F.ToSingularPascalCase(Model.Table.Name);
+Model.Vars.ModuleName = F.ToSingularKebabCase(Model.Table.Name);
@@ -1,5 +1,9 @@
 package common
 
+import (
+	"slices"
+)
+
 var csharpTypeMap = map[DataType]string{
 	DataTypeBoolean:   "bool",
 	DataTypeByte:      "byte",
@@ -26,3 +30,52 @@ var csharpTypeMap = map[DataType]string{
 	DataTypeBinary:    "byte[]",
 	DataTypeAny:       "object",
 }
+
+const (
+	// CSharpNullableReferences also marks nullable reference types with "?",
+	// for projects with nullable reference types enabled.
+	CSharpNullableReferences = "nrt"
+)
+
+var csharpUnsignedTypeMap = map[string]string{
+	"short": "ushort",
+	"int":   "uint",
+	"long":  "ulong",
+}
+
+var csharpValueTypes = []string{
+	"bool", "byte", "sbyte", "short", "ushort", "int", "uint", "long", "ulong",
+	"float", "double", "decimal", "DateTime", "DateTimeOffset", "DateOnly", "TimeOnly",
+	"TimeSpan", "Guid",
+}
+
+// CSharpType returns the C# type of the column with unsigned integers for
+// unsigned columns and T? for nullable value types. With the "nrt" option
+// nullable reference types are marked as well.
+func (s *ColumnSchema) CSharpType(opts ...string) (string, error) {
+	set, err := typeOptionSet("CSharpType", opts, CSharpNullableReferences)
+	if err != nil {
+		return "", err
+	}
+
+	t := s.TypeFor(LangCSharp)
+	if s.IsUnsigned {
+		if u, ok := csharpUnsignedTypeMap[t]; ok {
+			t = u
+		}
+	}
+
+	if s.IsNullable && (set[CSharpNullableReferences] || slices.Contains(csharpValueTypes, t)) {
+		return t + "?", nil
+	}
+	return t, nil
+}
+
+var csharpImportMap = map[string]string{
+	"DateTime":       "System",
+	"DateTimeOffset": "System",
+	"DateOnly":       "System",
+	"TimeOnly":       "System",
+	"TimeSpan":       "System",
+	"Guid":           "System",
+}
@@ -0,0 +1,33 @@
+package common
+
+var dartTypeMap = map[DataType]string{
+	DataTypeBoolean:   "bool",
+	DataTypeByte:      "int",
+	DataTypeInt16:     "int",
+	DataTypeInt24:     "int",
+	DataTypeInt32:     "int",
+	DataTypeInt64:     "int",
+	DataTypeFloat:     "double",
+	DataTypeDouble:    "double",
+	DataTypeDecimal:   "double",
+	DataTypeCurrency:  "double",
+	DataTypeDate:      "DateTime",
+	DataTypeTime:      "String",
+	DataTypeYear:      "int",
+	DataTypeDateTime:  "DateTime",
+	DataTypeTimeStamp: "DateTime",
+	DataTypeEnum:      "String",
+	DataTypeSet:       "String",
+	DataTypeGuid:      "String",
+	DataTypeUuid:      "String",
+	DataTypeString:    "String",
+	DataTypeJson:      "String",
+	DataTypeXml:       "String",
+	DataTypeBinary:    "List<int>",
+	DataTypeAny:       "dynamic",
+}
+
+var dartImportMap = map[string]string{
+	"Uint8List": "dart:typed_data",
+	"Decimal":   "package:decimal/decimal.dart",
+}
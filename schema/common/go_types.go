@@ -1,5 +1,9 @@
 package common
 
+import (
+	"strings"
+)
+
 var goTypeMap = map[DataType]string{
 	DataTypeBoolean:   "bool",
 	DataTypeByte:      "byte",
@@ -26,3 +30,61 @@ var goTypeMap = map[DataType]string{
 	DataTypeBinary:    "[]byte",
 	DataTypeAny:       "any",
 }
+
+const (
+	// GoNullSql types nullable columns as sql.NullXxx instead of pointers.
+	GoNullSql = "sql"
+)
+
+var goUnsignedTypeMap = map[string]string{
+	"int16": "uint16",
+	"int32": "uint32",
+	"int64": "uint64",
+}
+
+var goSqlNullTypeMap = map[string]string{
+	"bool":      "sql.NullBool",
+	"byte":      "sql.NullByte",
+	"int16":     "sql.NullInt16",
+	"int32":     "sql.NullInt32",
+	"int64":     "sql.NullInt64",
+	"float64":   "sql.NullFloat64",
+	"string":    "sql.NullString",
+	"time.Time": "sql.NullTime",
+}
+
+// GoType returns the Go type of the column with unsigned integers for unsigned
+// columns and a pointer for nullable columns, or sql.NullXxx with the "sql"
+// option. Types without a sql.NullXxx type become sql.Null[T]. Slices and any
+// are nullable as they are.
+func (s *ColumnSchema) GoType(opts ...string) (string, error) {
+	set, err := typeOptionSet("GoType", opts, GoNullSql)
+	if err != nil {
+		return "", err
+	}
+
+	t := s.TypeFor(LangGo)
+	if s.IsUnsigned {
+		if u, ok := goUnsignedTypeMap[t]; ok {
+			t = u
+		}
+	}
+
+	if !s.IsNullable || t == "any" || strings.HasPrefix(t, "[]") {
+		return t, nil
+	}
+	if set[GoNullSql] {
+		if n, ok := goSqlNullTypeMap[t]; ok {
+			return n, nil
+		}
+		return "sql.Null[" + t + "]", nil
+	}
+	return "*" + t, nil
+}
+
+// goImportMap maps the packages of Go types to their import paths.
+var goImportMap = map[string]string{
+	"time": "time",
+	"sql":  "database/sql",
+	"json": "encoding/json",
+}
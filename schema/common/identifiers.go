@@ -0,0 +1,276 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"crudify/utils"
+)
+
+const (
+	StyleCamel  = "camel"
+	StylePascal = "pascal"
+	StyleSnake  = "snake"
+	// StyleNone keeps the name as it is.
+	StyleNone = "none"
+)
+
+// columnStyles are the usual styles of field or property names.
+var columnStyles = map[string]string{
+	LangCSharp:     StylePascal,
+	LangJava:       StyleCamel,
+	LangGo:         StylePascal,
+	LangPython:     StyleSnake,
+	LangTypeScript: StyleCamel,
+	LangKotlin:     StyleCamel,
+	LangRust:       StyleSnake,
+	LangDart:       StyleCamel,
+	LangSwift:      StyleCamel,
+	LangPHP:        StyleCamel,
+}
+
+// NamingOptions configures the initialism-aware naming and the inflection of
+// words. Initialisms replaces the default initialisms of golint.
+// InitialismLanguages are the languages whose camel and pascal case
+// identifiers are initialism-aware, Go by default. Irregular maps singular to
+// plural forms and Uncountable lists words without a plural form, both in
+// addition to the built-in words.
+type NamingOptions struct {
+	Initialisms         []string          `yaml:"initialisms"`
+	InitialismLanguages []string          `yaml:"initialism-languages"`
+	Irregular           map[string]string `yaml:"irregular"`
+	Uncountable         []string          `yaml:"uncountable"`
+}
+
+// Merge returns the options with the lists set in other taking precedence,
+// the irregular and uncountable words of both are kept.
+func (o NamingOptions) Merge(other NamingOptions) NamingOptions {
+	if other.Initialisms != nil {
+		o.Initialisms = other.Initialisms
+	}
+	if other.InitialismLanguages != nil {
+		o.InitialismLanguages = other.InitialismLanguages
+	}
+	if len(other.Irregular) > 0 {
+		irregular := maps.Clone(o.Irregular)
+		if irregular == nil {
+			irregular = map[string]string{}
+		}
+		maps.Copy(irregular, other.Irregular)
+		o.Irregular = irregular
+	}
+	o.Uncountable = append(slices.Clone(o.Uncountable), other.Uncountable...)
+	return o
+}
+
+var initialismLanguages = []string{LangGo}
+
+// SetNamingOptions sets the initialisms, the initialism-aware languages and the
+// additional inflections.
+func SetNamingOptions(opts NamingOptions) error {
+	for _, lang := range opts.InitialismLanguages {
+		if _, ok := languages[lang]; !ok {
+			return fmt.Errorf("naming: unknown language: %s", lang)
+		}
+	}
+
+	utils.SetInitialisms(opts.Initialisms)
+	utils.SetInflections(opts.Irregular, opts.Uncountable)
+	initialismLanguages = opts.InitialismLanguages
+	if initialismLanguages == nil {
+		initialismLanguages = []string{LangGo}
+	}
+	return nil
+}
+
+// escapes are the escape syntaxes of the languages that have one.
+var escapes = map[string]func(name string) (string, bool){
+	LangCSharp: func(name string) (string, bool) { return "@" + name, true },
+	LangKotlin: func(name string) (string, bool) { return "`" + name + "`", true },
+	LangSwift:  func(name string) (string, bool) { return "`" + name + "`", true },
+	LangRust: func(name string) (string, bool) {
+		// These keywords can not be raw identifiers.
+		if slices.Contains([]string{"crate", "self", "Self", "super"}, name) {
+			return "", false
+		}
+		return "r#" + name, true
+	},
+}
+
+// IdentifierRules configures how SafeIdentifier handles the identifiers of a
+// language.
+type IdentifierRules struct {
+	// Escape uses the escape syntax of the language for keywords: @name in C#,
+	// `name` in Kotlin and Swift, r#name in Rust. It defaults to true for these
+	// languages.
+	Escape *bool `yaml:"escape"`
+	// Prefix and Suffix are added to keywords that are not escaped. Without
+	// both the suffix is "_".
+	Prefix string `yaml:"prefix"`
+	Suffix string `yaml:"suffix"`
+	// Renames replaces identifiers, keywords or not, e.g. class: clazz.
+	Renames map[string]string `yaml:"renames"`
+	// Keywords are reserved words in addition to the keywords of the language.
+	Keywords []string `yaml:"keywords"`
+	// DigitPrefix is added to identifiers starting with a digit, "_" by default.
+	DigitPrefix string `yaml:"digit-prefix"`
+}
+
+func (r IdentifierRules) merge(other IdentifierRules) IdentifierRules {
+	if other.Escape != nil {
+		r.Escape = other.Escape
+	}
+	if other.Prefix != "" || other.Suffix != "" {
+		r.Prefix, r.Suffix = other.Prefix, other.Suffix
+	}
+	if other.DigitPrefix != "" {
+		r.DigitPrefix = other.DigitPrefix
+	}
+	if len(other.Renames) > 0 {
+		renames := maps.Clone(r.Renames)
+		if renames == nil {
+			renames = map[string]string{}
+		}
+		maps.Copy(renames, other.Renames)
+		r.Renames = renames
+	}
+	r.Keywords = append(slices.Clone(r.Keywords), other.Keywords...)
+	return r
+}
+
+// IdentifierOptions are the identifier rules keyed by language.
+type IdentifierOptions map[string]IdentifierRules
+
+// Merge returns the options with the rules set in other taking precedence.
+func (o IdentifierOptions) Merge(other IdentifierOptions) IdentifierOptions {
+	merged := IdentifierOptions{}
+	for lang, rules := range o {
+		merged[lang] = rules
+	}
+	for lang, rules := range other {
+		merged[lang] = merged[lang].merge(rules)
+	}
+	return merged
+}
+
+// identifierOptions is set by the generator before rendering starts and only
+// read while rendering.
+var identifierOptions IdentifierOptions
+
+// SetIdentifierOptions sets the rules used by SafeIdentifier.
+func SetIdentifierOptions(opts IdentifierOptions) error {
+	for lang := range opts {
+		if _, ok := languages[lang]; !ok {
+			return fmt.Errorf("identifiers: unknown language: %s", lang)
+		}
+	}
+	identifierOptions = opts
+	return nil
+}
+
+// IsKeyword reports whether the name is a reserved word of the language,
+// including the configured keywords. PHP keywords are case-insensitive.
+func IsKeyword(lang, name string) bool {
+	if lang == LangPHP {
+		name = strings.ToLower(name)
+	}
+	return slices.Contains(keywordLists[lang], name) ||
+		slices.Contains(identifierOptions[lang].Keywords, name)
+}
+
+// SafeIdentifier returns the name as a valid identifier of the language. Other
+// characters than letters, digits and underscores are replaced by underscores,
+// a leading digit gets a prefix, and keywords are escaped or get a prefix or
+// suffix, unless the configured renames have another name for the name.
+func SafeIdentifier(lang, name string) (string, error) {
+	if _, ok := languages[lang]; !ok {
+		return "", fmt.Errorf("unknown language %s, expected one of %s", lang, strings.Join(Languages(), ", "))
+	}
+
+	rules := identifierOptions[lang]
+	if renamed, ok := rules.Renames[name]; ok {
+		return renamed, nil
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		prefix := rules.DigitPrefix
+		if prefix == "" {
+			prefix = "_"
+		}
+		name = prefix + name
+	}
+
+	if !IsKeyword(lang, name) {
+		return name, nil
+	}
+
+	if escape, ok := escapes[lang]; ok && (rules.Escape == nil || *rules.Escape) {
+		if escaped, ok := escape(name); ok {
+			return escaped, nil
+		}
+	}
+
+	if rules.Prefix == "" && rules.Suffix == "" {
+		return name + "_", nil
+	}
+	return rules.Prefix + name + rules.Suffix, nil
+}
+
+// styleName returns the name in the style, or in the default style if no
+// style is given. Camel and pascal case are initialism-aware for the
+// initialism languages.
+func styleName(lang, name string, defaultStyle string, style []string) (string, error) {
+	s := defaultStyle
+	if len(style) > 0 {
+		s = style[0]
+	}
+	initialisms := slices.Contains(initialismLanguages, lang)
+	switch s {
+	case StyleCamel:
+		if initialisms {
+			return utils.ToCamelCaseInitialisms(name), nil
+		}
+		return utils.ToCamelCase(name), nil
+	case StylePascal:
+		if initialisms {
+			return utils.ToPascalCaseInitialisms(name), nil
+		}
+		return utils.ToPascalCase(name), nil
+	case StyleSnake:
+		return utils.ToSnakeCase(name), nil
+	case StyleNone:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown naming style %s", s)
+	}
+}
+
+// SafeIdentifier returns the name of the column as a field or property name of
+// the language, in the usual style of the language or in the given style.
+func (s *ColumnSchema) SafeIdentifier(lang string, style ...string) (string, error) {
+	name, err := styleName(lang, s.Name, columnStyles[lang], style)
+	if err != nil {
+		return "", err
+	}
+	return SafeIdentifier(lang, name)
+}
+
+// SafeIdentifier returns the name of the table as a type name of the language,
+// in pascal case or in the given style.
+func (s *TableSchema) SafeIdentifier(lang string, style ...string) (string, error) {
+	name, err := styleName(lang, s.Name, StylePascal, style)
+	if err != nil {
+		return "", err
+	}
+	return SafeIdentifier(lang, name)
+}
@@ -26,3 +26,64 @@ var javaTypeMap = map[DataType]string{
 	DataTypeBinary:    "Byte[]",
 	DataTypeAny:       "Object",
 }
+
+const (
+	// JavaBoxed keeps the boxed types for columns that are not nullable.
+	JavaBoxed = "boxed"
+)
+
+// javaUnsignedTypeMap widens the types of unsigned columns to a type holding
+// the whole unsigned range.
+var javaUnsignedTypeMap = map[string]string{
+	"Byte":    "Short",
+	"Short":   "Integer",
+	"Integer": "Long",
+	"Long":    "BigInteger",
+}
+
+var javaPrimitiveTypeMap = map[string]string{
+	"Boolean": "boolean",
+	"Byte":    "byte",
+	"Short":   "short",
+	"Integer": "int",
+	"Long":    "long",
+	"Float":   "float",
+	"Double":  "double",
+}
+
+// JavaType returns the Java type of the column with a wider type for unsigned
+// columns, a boxed type for nullable columns and a primitive type otherwise.
+// The "boxed" option keeps boxed types for all columns.
+func (s *ColumnSchema) JavaType(opts ...string) (string, error) {
+	set, err := typeOptionSet("JavaType", opts, JavaBoxed)
+	if err != nil {
+		return "", err
+	}
+
+	t := s.TypeFor(LangJava)
+	if s.IsUnsigned {
+		if u, ok := javaUnsignedTypeMap[t]; ok {
+			t = u
+		}
+	}
+
+	if !s.IsNullable && !set[JavaBoxed] {
+		if p, ok := javaPrimitiveTypeMap[t]; ok {
+			return p, nil
+		}
+	}
+	return t, nil
+}
+
+var javaImportMap = map[string]string{
+	"BigDecimal":     "java.math.BigDecimal",
+	"BigInteger":     "java.math.BigInteger",
+	"LocalDate":      "java.time.LocalDate",
+	"LocalTime":      "java.time.LocalTime",
+	"LocalDateTime":  "java.time.LocalDateTime",
+	"OffsetDateTime": "java.time.OffsetDateTime",
+	"Instant":        "java.time.Instant",
+	"Year":           "java.time.Year",
+	"Date":           "java.util.Date",
+	"UUID":           "java.util.UUID",
+}
@@ -0,0 +1,83 @@
+package common
+
+// keywordLists are the reserved words of the languages, which can not be used
+// as identifiers without escaping.
+var keywordLists = map[string][]string{
+	LangCSharp: {
+		"abstract", "as", "base", "bool", "break", "byte", "case", "catch", "char", "checked",
+		"class", "const", "continue", "decimal", "default", "delegate", "do", "double", "else",
+		"enum", "event", "explicit", "extern", "false", "finally", "fixed", "float", "for",
+		"foreach", "goto", "if", "implicit", "in", "int", "interface", "internal", "is", "lock",
+		"long", "namespace", "new", "null", "object", "operator", "out", "override", "params",
+		"private", "protected", "public", "readonly", "ref", "return", "sbyte", "sealed",
+		"short", "sizeof", "stackalloc", "static", "string", "struct", "switch", "this",
+		"throw", "true", "try", "typeof", "uint", "ulong", "unchecked", "unsafe", "ushort",
+		"using", "virtual", "void", "volatile", "while",
+	},
+	LangJava: {
+		"abstract", "assert", "boolean", "break", "byte", "case", "catch", "char", "class",
+		"const", "continue", "default", "do", "double", "else", "enum", "extends", "final",
+		"finally", "float", "for", "goto", "if", "implements", "import", "instanceof", "int",
+		"interface", "long", "native", "new", "package", "private", "protected", "public",
+		"return", "short", "static", "strictfp", "super", "switch", "synchronized", "this",
+		"throw", "throws", "transient", "try", "void", "volatile", "while", "true", "false",
+		"null", "_",
+	},
+	LangGo: {
+		"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough",
+		"for", "func", "go", "goto", "if", "import", "interface", "map", "package", "range",
+		"return", "select", "struct", "switch", "type", "var",
+	},
+	LangPython: {
+		"False", "None", "True", "and", "as", "assert", "async", "await", "break", "class",
+		"continue", "def", "del", "elif", "else", "except", "finally", "for", "from", "global",
+		"if", "import", "in", "is", "lambda", "nonlocal", "not", "or", "pass", "raise",
+		"return", "try", "while", "with", "yield",
+	},
+	LangTypeScript: {
+		"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete",
+		"do", "else", "enum", "export", "extends", "false", "finally", "for", "function", "if",
+		"import", "in", "instanceof", "new", "null", "return", "super", "switch", "this",
+		"throw", "true", "try", "typeof", "var", "void", "while", "with", "implements",
+		"interface", "let", "package", "private", "protected", "public", "static", "yield",
+	},
+	LangKotlin: {
+		"as", "break", "class", "continue", "do", "else", "false", "for", "fun", "if", "in",
+		"interface", "is", "null", "object", "package", "return", "super", "this", "throw",
+		"true", "try", "typealias", "typeof", "val", "var", "when", "while",
+	},
+	LangRust: {
+		"as", "break", "const", "continue", "crate", "else", "enum", "extern", "false", "fn",
+		"for", "if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub", "ref",
+		"return", "self", "Self", "static", "struct", "super", "trait", "true", "type",
+		"unsafe", "use", "where", "while", "async", "await", "dyn", "abstract", "become",
+		"box", "do", "final", "macro", "override", "priv", "typeof", "unsized", "virtual",
+		"yield", "try", "gen",
+	},
+	LangDart: {
+		"assert", "break", "case", "catch", "class", "const", "continue", "default", "do",
+		"else", "enum", "extends", "false", "final", "finally", "for", "if", "in", "is", "new",
+		"null", "rethrow", "return", "super", "switch", "this", "throw", "true", "try", "var",
+		"void", "while", "with",
+	},
+	LangSwift: {
+		"associatedtype", "class", "deinit", "enum", "extension", "fileprivate", "func",
+		"import", "init", "inout", "internal", "let", "open", "operator", "private",
+		"precedencegroup", "protocol", "public", "rethrows", "static", "struct", "subscript",
+		"typealias", "var", "break", "case", "catch", "continue", "default", "defer", "do",
+		"else", "fallthrough", "for", "guard", "if", "in", "repeat", "return", "throw",
+		"switch", "where", "while", "Any", "as", "await", "false", "is", "nil", "self",
+		"Self", "super", "throws", "true", "try",
+	},
+	LangPHP: {
+		"abstract", "and", "array", "as", "break", "callable", "case", "catch", "class",
+		"clone", "const", "continue", "declare", "default", "do", "echo", "else", "elseif",
+		"empty", "enddeclare", "endfor", "endforeach", "endif", "endswitch", "endwhile", "enum",
+		"eval", "exit", "extends", "final", "finally", "fn", "for", "foreach", "function",
+		"global", "goto", "if", "implements", "include", "include_once", "instanceof",
+		"insteadof", "interface", "isset", "list", "match", "namespace", "new", "or", "print",
+		"private", "protected", "public", "readonly", "require", "require_once", "return",
+		"static", "switch", "throw", "trait", "try", "unset", "use", "var", "while", "xor",
+		"yield",
+	},
+}
@@ -0,0 +1,39 @@
+package common
+
+var kotlinTypeMap = map[DataType]string{
+	DataTypeBoolean:   "Boolean",
+	DataTypeByte:      "Byte",
+	DataTypeInt16:     "Short",
+	DataTypeInt24:     "Int",
+	DataTypeInt32:     "Int",
+	DataTypeInt64:     "Long",
+	DataTypeFloat:     "Float",
+	DataTypeDouble:    "Double",
+	DataTypeDecimal:   "BigDecimal",
+	DataTypeCurrency:  "BigDecimal",
+	DataTypeDate:      "LocalDate",
+	DataTypeTime:      "LocalTime",
+	DataTypeYear:      "Year",
+	DataTypeDateTime:  "LocalDateTime",
+	DataTypeTimeStamp: "Instant",
+	DataTypeEnum:      "String",
+	DataTypeSet:       "String",
+	DataTypeGuid:      "String",
+	DataTypeUuid:      "String",
+	DataTypeString:    "String",
+	DataTypeJson:      "String",
+	DataTypeXml:       "String",
+	DataTypeBinary:    "ByteArray",
+	DataTypeAny:       "Any",
+}
+
+var kotlinImportMap = map[string]string{
+	"BigDecimal":    "java.math.BigDecimal",
+	"BigInteger":    "java.math.BigInteger",
+	"LocalDate":     "java.time.LocalDate",
+	"LocalTime":     "java.time.LocalTime",
+	"LocalDateTime": "java.time.LocalDateTime",
+	"Instant":       "java.time.Instant",
+	"Year":          "java.time.Year",
+	"UUID":          "java.util.UUID",
+}
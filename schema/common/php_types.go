@@ -0,0 +1,28 @@
+package common
+
+var phpTypeMap = map[DataType]string{
+	DataTypeBoolean:   "bool",
+	DataTypeByte:      "int",
+	DataTypeInt16:     "int",
+	DataTypeInt24:     "int",
+	DataTypeInt32:     "int",
+	DataTypeInt64:     "int",
+	DataTypeFloat:     "float",
+	DataTypeDouble:    "float",
+	DataTypeDecimal:   "string",
+	DataTypeCurrency:  "string",
+	DataTypeDate:      "\\DateTimeImmutable",
+	DataTypeTime:      "\\DateTimeImmutable",
+	DataTypeYear:      "int",
+	DataTypeDateTime:  "\\DateTimeImmutable",
+	DataTypeTimeStamp: "\\DateTimeImmutable",
+	DataTypeEnum:      "string",
+	DataTypeSet:       "string",
+	DataTypeGuid:      "string",
+	DataTypeUuid:      "string",
+	DataTypeString:    "string",
+	DataTypeJson:      "string",
+	DataTypeXml:       "string",
+	DataTypeBinary:    "string",
+	DataTypeAny:       "mixed",
+}
@@ -26,3 +26,37 @@ var pythonTypeMap = map[DataType]string{
 	DataTypeBinary:    "bytes",
 	DataTypeAny:       "any",
 }
+
+const (
+	// PythonUnion types nullable columns as T | None instead of Optional[T].
+	PythonUnion = "union"
+)
+
+// PythonType returns the Python type of the column, Optional[T] or T | None
+// with the "union" option if it is nullable. Python integers have no range,
+// so unsigned columns need no other type.
+func (s *ColumnSchema) PythonType(opts ...string) (string, error) {
+	set, err := typeOptionSet("PythonType", opts, PythonUnion)
+	if err != nil {
+		return "", err
+	}
+
+	t := s.TypeFor(LangPython)
+	if !s.IsNullable || t == "any" {
+		return t, nil
+	}
+	if set[PythonUnion] {
+		return t + " | None", nil
+	}
+	return "Optional[" + t + "]", nil
+}
+
+var pythonImportMap = map[string]string{
+	"Decimal":  "from decimal import Decimal",
+	"date":     "from datetime import date",
+	"time":     "from datetime import time",
+	"datetime": "from datetime import datetime",
+	"Optional": "from typing import Optional",
+	"Any":      "from typing import Any",
+	"UUID":     "from uuid import UUID",
+}
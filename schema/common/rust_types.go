@@ -0,0 +1,38 @@
+package common
+
+var rustTypeMap = map[DataType]string{
+	DataTypeBoolean:   "bool",
+	DataTypeByte:      "u8",
+	DataTypeInt16:     "i16",
+	DataTypeInt24:     "i32",
+	DataTypeInt32:     "i32",
+	DataTypeInt64:     "i64",
+	DataTypeFloat:     "f32",
+	DataTypeDouble:    "f64",
+	DataTypeDecimal:   "Decimal",
+	DataTypeCurrency:  "Decimal",
+	DataTypeDate:      "NaiveDate",
+	DataTypeTime:      "NaiveTime",
+	DataTypeYear:      "i16",
+	DataTypeDateTime:  "NaiveDateTime",
+	DataTypeTimeStamp: "DateTime<Utc>",
+	DataTypeEnum:      "String",
+	DataTypeSet:       "String",
+	DataTypeGuid:      "String",
+	DataTypeUuid:      "String",
+	DataTypeString:    "String",
+	DataTypeJson:      "String",
+	DataTypeXml:       "String",
+	DataTypeBinary:    "Vec<u8>",
+	DataTypeAny:       "serde_json::Value",
+}
+
+var rustImportMap = map[string]string{
+	"Decimal":       "rust_decimal::Decimal",
+	"NaiveDate":     "chrono::NaiveDate",
+	"NaiveTime":     "chrono::NaiveTime",
+	"NaiveDateTime": "chrono::NaiveDateTime",
+	"DateTime":      "chrono::DateTime",
+	"Utc":           "chrono::Utc",
+	"Uuid":          "uuid::Uuid",
+}
@@ -46,44 +46,72 @@ type ColumnSchema struct {
 	HasDefault      bool
 	IsPrimaryKey    bool
 	Comment         string
+	// IsVirtual marks a computed column added by a script.
+	IsVirtual bool
 }
 
 func (s *ColumnSchema) CSharpDataType() string {
-	t, ok := csharpTypeMap[s.DataType]
-	if ok {
-		return t
-	}
-	return "object"
+	return s.TypeFor(LangCSharp)
 }
 
 func (s *ColumnSchema) JavaDataType() string {
-	t, ok := javaTypeMap[s.DataType]
-	if ok {
-		return t
-	}
-	return "Object"
+	return s.TypeFor(LangJava)
 }
 
 func (s *ColumnSchema) GoDataType() string {
-	t, ok := goTypeMap[s.DataType]
-	if ok {
-		return t
-	}
-	return "any"
+	return s.TypeFor(LangGo)
 }
 
 func (s *ColumnSchema) PythonDataType() string {
-	t, ok := pythonTypeMap[s.DataType]
-	if ok {
-		return t
+	return s.TypeFor(LangPython)
+}
+
+// KotlinDataType returns the Kotlin type of the column, T? if it is nullable.
+func (s *ColumnSchema) KotlinDataType() string {
+	return s.TypeFor(LangKotlin)
+}
+
+// RustDataType returns the Rust type of the column, Option<T> if it is nullable.
+func (s *ColumnSchema) RustDataType() string {
+	return s.TypeFor(LangRust)
+}
+
+// DartDataType returns the Dart type of the column, T? if it is nullable.
+func (s *ColumnSchema) DartDataType() string {
+	return s.TypeFor(LangDart)
+}
+
+// SwiftDataType returns the Swift type of the column, T? if it is nullable.
+func (s *ColumnSchema) SwiftDataType() string {
+	return s.TypeFor(LangSwift)
+}
+
+// PHPDataType returns the PHP type of the column, ?T if it is nullable.
+func (s *ColumnSchema) PHPDataType() string {
+	return s.TypeFor(LangPHP)
+}
+
+// TypeScriptDataType returns the TypeScript type of the column, a nullable
+// column is typed as T | null unless configured otherwise.
+func (s *ColumnSchema) TypeScriptDataType() string {
+	return s.TypeFor(LangTypeScript)
+}
+
+// TypeScriptOptionalMark returns "?" for a nullable column if nullable columns
+// are typed as optional properties, as in {{.NameCamelCase}}{{.TypeScriptOptionalMark}}.
+func (s *ColumnSchema) TypeScriptOptionalMark() string {
+	if s.IsNullable && typeOptions.TypeScript.Nullable == TypeScriptNullableOptional {
+		return "?"
 	}
-	return "any"
+	return ""
 }
 
 type TableSchema struct {
 	Name    string
 	Columns []*ColumnSchema
 	Comment string
+	// IsVirtual marks a table added by a script.
+	IsVirtual bool
 }
 
 func (s *TableSchema) PrimaryKeyColumn() *ColumnSchema {
@@ -95,6 +123,18 @@ func (s *TableSchema) PrimaryKeyColumn() *ColumnSchema {
 	return nil
 }
 
+// Clone returns a copy of the table and its columns, so that scripts can modify
+// one rendering's table without affecting others.
+func (s *TableSchema) Clone() *TableSchema {
+	table := *s
+	table.Columns = make([]*ColumnSchema, len(s.Columns))
+	for i, column := range s.Columns {
+		c := *column
+		table.Columns[i] = &c
+	}
+	return &table
+}
+
 type SchemaProvider interface {
 	io.Closer
 	GetTables(database string) ([]*TableSchema, error)
@@ -35,3 +35,139 @@ func (s *TableSchema) NameSnakeCase() string {
 func (s *TableSchema) NameKebabCase() string {
 	return utils.ToKebabCase(s.Name)
 }
+
+func (s *ColumnSchema) NameCamelCaseInitialisms() string {
+	return utils.ToCamelCaseInitialisms(s.Name)
+}
+
+func (s *ColumnSchema) NamePascalCaseInitialisms() string {
+	return utils.ToPascalCaseInitialisms(s.Name)
+}
+
+func (s *TableSchema) NameCamelCaseInitialisms() string {
+	return utils.ToCamelCaseInitialisms(s.Name)
+}
+
+func (s *TableSchema) NamePascalCaseInitialisms() string {
+	return utils.ToPascalCaseInitialisms(s.Name)
+}
+
+func (s *ColumnSchema) NameScreamingSnakeCase() string {
+	return utils.ToScreamingSnakeCase(s.Name)
+}
+
+func (s *ColumnSchema) NamePluralScreamingSnakeCase() string {
+	return utils.ToPluralScreamingSnakeCase(s.Name)
+}
+
+func (s *ColumnSchema) NameSingularScreamingSnakeCase() string {
+	return utils.ToSingularScreamingSnakeCase(s.Name)
+}
+
+func (s *ColumnSchema) NameDotCase() string {
+	return utils.ToDotCase(s.Name)
+}
+
+func (s *ColumnSchema) NamePluralDotCase() string {
+	return utils.ToPluralDotCase(s.Name)
+}
+
+func (s *ColumnSchema) NameSingularDotCase() string {
+	return utils.ToSingularDotCase(s.Name)
+}
+
+func (s *ColumnSchema) NameTitleCase() string {
+	return utils.ToTitleCase(s.Name)
+}
+
+func (s *ColumnSchema) NamePluralTitleCase() string {
+	return utils.ToPluralTitleCase(s.Name)
+}
+
+func (s *ColumnSchema) NameSingularTitleCase() string {
+	return utils.ToSingularTitleCase(s.Name)
+}
+
+func (s *ColumnSchema) NamePathCase() string {
+	return utils.ToPathCase(s.Name)
+}
+
+func (s *ColumnSchema) NamePluralPathCase() string {
+	return utils.ToPluralPathCase(s.Name)
+}
+
+func (s *ColumnSchema) NameSingularPathCase() string {
+	return utils.ToSingularPathCase(s.Name)
+}
+
+func (s *ColumnSchema) NameFlatCase() string {
+	return utils.ToFlatCase(s.Name)
+}
+
+func (s *ColumnSchema) NamePluralFlatCase() string {
+	return utils.ToPluralFlatCase(s.Name)
+}
+
+func (s *ColumnSchema) NameSingularFlatCase() string {
+	return utils.ToSingularFlatCase(s.Name)
+}
+
+func (s *TableSchema) NameScreamingSnakeCase() string {
+	return utils.ToScreamingSnakeCase(s.Name)
+}
+
+func (s *TableSchema) NamePluralScreamingSnakeCase() string {
+	return utils.ToPluralScreamingSnakeCase(s.Name)
+}
+
+func (s *TableSchema) NameSingularScreamingSnakeCase() string {
+	return utils.ToSingularScreamingSnakeCase(s.Name)
+}
+
+func (s *TableSchema) NameDotCase() string {
+	return utils.ToDotCase(s.Name)
+}
+
+func (s *TableSchema) NamePluralDotCase() string {
+	return utils.ToPluralDotCase(s.Name)
+}
+
+func (s *TableSchema) NameSingularDotCase() string {
+	return utils.ToSingularDotCase(s.Name)
+}
+
+func (s *TableSchema) NameTitleCase() string {
+	return utils.ToTitleCase(s.Name)
+}
+
+func (s *TableSchema) NamePluralTitleCase() string {
+	return utils.ToPluralTitleCase(s.Name)
+}
+
+func (s *TableSchema) NameSingularTitleCase() string {
+	return utils.ToSingularTitleCase(s.Name)
+}
+
+func (s *TableSchema) NamePathCase() string {
+	return utils.ToPathCase(s.Name)
+}
+
+func (s *TableSchema) NamePluralPathCase() string {
+	return utils.ToPluralPathCase(s.Name)
+}
+
+func (s *TableSchema) NameSingularPathCase() string {
+	return utils.ToSingularPathCase(s.Name)
+}
+
+func (s *TableSchema) NameFlatCase() string {
+	return utils.ToFlatCase(s.Name)
+}
+
+func (s *TableSchema) NamePluralFlatCase() string {
+	return utils.ToPluralFlatCase(s.Name)
+}
+
+func (s *TableSchema) NameSingularFlatCase() string {
+	return utils.ToSingularFlatCase(s.Name)
+}
@@ -0,0 +1,35 @@
+package common
+
+var swiftTypeMap = map[DataType]string{
+	DataTypeBoolean:   "Bool",
+	DataTypeByte:      "UInt8",
+	DataTypeInt16:     "Int16",
+	DataTypeInt24:     "Int32",
+	DataTypeInt32:     "Int32",
+	DataTypeInt64:     "Int64",
+	DataTypeFloat:     "Float",
+	DataTypeDouble:    "Double",
+	DataTypeDecimal:   "Decimal",
+	DataTypeCurrency:  "Decimal",
+	DataTypeDate:      "Date",
+	DataTypeTime:      "Date",
+	DataTypeYear:      "Int16",
+	DataTypeDateTime:  "Date",
+	DataTypeTimeStamp: "Date",
+	DataTypeEnum:      "String",
+	DataTypeSet:       "String",
+	DataTypeGuid:      "String",
+	DataTypeUuid:      "String",
+	DataTypeString:    "String",
+	DataTypeJson:      "String",
+	DataTypeXml:       "String",
+	DataTypeBinary:    "Data",
+	DataTypeAny:       "Any",
+}
+
+var swiftImportMap = map[string]string{
+	"Date":    "Foundation",
+	"Decimal": "Foundation",
+	"Data":    "Foundation",
+	"UUID":    "Foundation",
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"fmt"
+)
+
+// TypeOptions configures the language types of all columns. Overrides are
+// keyed by language, the first matching override of a language is used.
+type TypeOptions struct {
+	TypeScript TypeScriptOptions         `yaml:"typescript"`
+	Overrides  map[string][]TypeOverride `yaml:"overrides"`
+}
+
+// typeOptions is set by the generator before rendering starts and only read
+// while rendering.
+var typeOptions TypeOptions
+
+// SetTypeOptions sets the options used by the type methods of ColumnSchema.
+func SetTypeOptions(opts TypeOptions) error {
+	err := opts.TypeScript.validate()
+	if err != nil {
+		return err
+	}
+	for lang, overrides := range opts.Overrides {
+		if _, ok := languages[lang]; !ok {
+			return fmt.Errorf("type overrides: unknown language: %s", lang)
+		}
+		for i := range overrides {
+			err = overrides[i].validate(lang)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	typeOptions = opts
+	return nil
+}
+
+// Merge returns the options with the options set in other taking precedence,
+// the overrides of other are matched first.
+func (o TypeOptions) Merge(other TypeOptions) TypeOptions {
+	o.TypeScript = o.TypeScript.merge(other.TypeScript)
+
+	overrides := map[string][]TypeOverride{}
+	for lang, list := range other.Overrides {
+		overrides[lang] = append(overrides[lang], list...)
+	}
+	for lang, list := range o.Overrides {
+		overrides[lang] = append(overrides[lang], list...)
+	}
+	o.Overrides = overrides
+	return o
+}
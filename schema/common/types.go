@@ -0,0 +1,234 @@
+package common
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var reTypeName = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_.]*`)
+
+const (
+	LangCSharp     = "csharp"
+	LangJava       = "java"
+	LangGo         = "go"
+	LangPython     = "python"
+	LangTypeScript = "typescript"
+	LangKotlin     = "kotlin"
+	LangRust       = "rust"
+	LangDart       = "dart"
+	LangSwift      = "swift"
+	LangPHP        = "php"
+)
+
+// languageTypes maps the data types of columns to the types of a language.
+type languageTypes struct {
+	typeMap  map[DataType]string
+	fallback string
+	// base returns the type of a column before overrides, it defaults to the
+	// type map lookup.
+	base func(s *ColumnSchema) string
+	// nullable returns the type of a nullable column, nil if the language
+	// types do not depend on nullability.
+	nullable func(t string) string
+	// imports maps the names within types to the import they need. Qualified
+	// names such as time.Time are also looked up by their qualifier.
+	imports map[string]string
+}
+
+var languages = map[string]*languageTypes{
+	LangCSharp: {typeMap: csharpTypeMap, fallback: "object", imports: csharpImportMap},
+	LangJava:   {typeMap: javaTypeMap, fallback: "Object", imports: javaImportMap},
+	LangGo:     {typeMap: goTypeMap, fallback: "any", imports: goImportMap},
+	LangPython: {typeMap: pythonTypeMap, fallback: "any", imports: pythonImportMap},
+	LangTypeScript: {
+		typeMap:  typescriptTypeMap,
+		fallback: "any",
+		base:     typescriptBaseType,
+		nullable: typescriptNullable,
+	},
+	LangKotlin: {typeMap: kotlinTypeMap, fallback: "Any", nullable: suffixNullable("?"), imports: kotlinImportMap},
+	LangRust: {typeMap: rustTypeMap, fallback: "serde_json::Value", imports: rustImportMap, nullable: func(t string) string {
+		return "Option<" + t + ">"
+	}},
+	LangDart:  {typeMap: dartTypeMap, fallback: "dynamic", nullable: suffixNullable("?", "dynamic"), imports: dartImportMap},
+	LangSwift: {typeMap: swiftTypeMap, fallback: "Any", nullable: suffixNullable("?"), imports: swiftImportMap},
+	LangPHP: {typeMap: phpTypeMap, fallback: "mixed", nullable: func(t string) string {
+		if t == "mixed" {
+			return t
+		}
+		return "?" + t
+	}},
+}
+
+// Languages returns the names of the languages known to TypeFor.
+func Languages() []string {
+	names := []string{}
+	for name := range languages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func suffixNullable(suffix string, except ...string) func(string) string {
+	return func(t string) string {
+		if slices.Contains(except, t) {
+			return t
+		}
+		return t + suffix
+	}
+}
+
+// TypeOverride replaces the type of the columns matching all of its criteria.
+// NativeType and Column are case-insensitive glob patterns as in path.Match.
+// Import is the import the type needs, if it is not a known one.
+type TypeOverride struct {
+	DataType   DataType `yaml:"data-type"`
+	NativeType string   `yaml:"native-type"`
+	Column     string   `yaml:"column"`
+	Type       string   `yaml:"type"`
+	Import     string   `yaml:"import"`
+}
+
+func (o *TypeOverride) validate(lang string) error {
+	if o.Type == "" {
+		return fmt.Errorf("%s type override: type is required", lang)
+	}
+	if o.DataType == "" && o.NativeType == "" && o.Column == "" {
+		return fmt.Errorf("%s type override %s: one of data-type, native-type or column is required", lang, o.Type)
+	}
+	for _, pattern := range []string{o.NativeType, o.Column} {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("%s type override %s: invalid pattern %s: %w", lang, o.Type, pattern, err)
+		}
+	}
+	return nil
+}
+
+func (o *TypeOverride) matches(s *ColumnSchema) bool {
+	if o.DataType != "" && o.DataType != s.DataType {
+		return false
+	}
+	if o.NativeType != "" && !matchFold(o.NativeType, s.NativeType) {
+		return false
+	}
+	if o.Column != "" && !matchFold(o.Column, s.Name) {
+		return false
+	}
+	return true
+}
+
+func matchFold(pattern, name string) bool {
+	ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return ok
+}
+
+// TypeFor returns the type of the column in the language, applying the
+// configured type overrides and the nullability rules of the language. It
+// returns "" for an unknown language.
+func (s *ColumnSchema) TypeFor(lang string) string {
+	l, ok := languages[lang]
+	if !ok {
+		return ""
+	}
+
+	t := s.baseType(l)
+	if override := s.override(lang); override != nil {
+		t = override.Type
+	}
+
+	if s.IsNullable && l.nullable != nil {
+		return l.nullable(t)
+	}
+	return t
+}
+
+func (s *ColumnSchema) override(lang string) *TypeOverride {
+	overrides := typeOptions.Overrides[lang]
+	for i := range overrides {
+		if overrides[i].matches(s) {
+			return &overrides[i]
+		}
+	}
+	return nil
+}
+
+// typeVariant returns the type of the column as returned by the nullable and
+// unsigned aware type method of the language, if it has one.
+func (s *ColumnSchema) typeVariant(lang string, opts []string) (string, error) {
+	switch lang {
+	case LangGo:
+		return s.GoType(opts...)
+	case LangJava:
+		return s.JavaType(opts...)
+	case LangCSharp:
+		return s.CSharpType(opts...)
+	case LangPython:
+		return s.PythonType(opts...)
+	}
+	if len(opts) > 0 {
+		return "", fmt.Errorf("%s types have no options", lang)
+	}
+	return s.TypeFor(lang), nil
+}
+
+// Imports returns the sorted imports needed by the column types of the table
+// in the language. The options select the type variants as for GoType,
+// JavaType, CSharpType and PythonType, whose types are used for these
+// languages.
+func (s *TableSchema) Imports(lang string, opts ...string) ([]string, error) {
+	l, ok := languages[lang]
+	if !ok {
+		return nil, fmt.Errorf("unknown language %s, expected one of %s", lang, strings.Join(Languages(), ", "))
+	}
+
+	imports := []string{}
+	for _, column := range s.Columns {
+		if override := column.override(lang); override != nil && override.Import != "" {
+			imports = append(imports, override.Import)
+		}
+
+		t, err := column.typeVariant(lang, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range reTypeName.FindAllString(t, -1) {
+			if imp, ok := l.imports[name]; ok {
+				imports = append(imports, imp)
+			} else if qualifier, _, ok := strings.Cut(name, "."); ok && l.imports[qualifier] != "" {
+				imports = append(imports, l.imports[qualifier])
+			}
+		}
+	}
+
+	slices.Sort(imports)
+	return slices.Compact(imports), nil
+}
+
+func (s *ColumnSchema) baseType(l *languageTypes) string {
+	if l.base != nil {
+		return l.base(s)
+	}
+	t, ok := l.typeMap[s.DataType]
+	if !ok {
+		return l.fallback
+	}
+	return t
+}
+
+// typeOptionSet returns the type options given to a type method as a set,
+// rejecting options other than the allowed ones.
+func typeOptionSet(method string, opts []string, allowed ...string) (map[string]bool, error) {
+	set := map[string]bool{}
+	for _, opt := range opts {
+		if !slices.Contains(allowed, opt) {
+			return nil, fmt.Errorf("%s: unknown option %s, expected one of %s", method, opt, strings.Join(allowed, ", "))
+		}
+		set[opt] = true
+	}
+	return set, nil
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"fmt"
+)
+
+const (
+	TypeScriptNullableUnion    = "union"
+	TypeScriptNullableOptional = "optional"
+	TypeScriptNullableNone     = "none"
+
+	TypeScriptNumber = "number"
+	TypeScriptBigInt = "bigint"
+	TypeScriptString = "string"
+)
+
+var typescriptTypeMap = map[DataType]string{
+	DataTypeBoolean:   "boolean",
+	DataTypeByte:      "number",
+	DataTypeInt16:     "number",
+	DataTypeInt24:     "number",
+	DataTypeInt32:     "number",
+	DataTypeInt64:     "number",
+	DataTypeFloat:     "number",
+	DataTypeDouble:    "number",
+	DataTypeDecimal:   "number",
+	DataTypeCurrency:  "number",
+	DataTypeDate:      "string",
+	DataTypeTime:      "string",
+	DataTypeYear:      "number",
+	DataTypeDateTime:  "string",
+	DataTypeTimeStamp: "string",
+	DataTypeEnum:      "string",
+	DataTypeSet:       "string",
+	DataTypeGuid:      "string",
+	DataTypeUuid:      "string",
+	DataTypeString:    "string",
+	DataTypeJson:      "any",
+	DataTypeXml:       "string",
+	DataTypeBinary:    "string",
+	DataTypeAny:       "any",
+}
+
+// TypeScriptOptions configures the TypeScript types of columns.
+type TypeScriptOptions struct {
+	// Nullable is how nullable columns are typed: "union" for T | null, the
+	// default, "optional" for optional properties or "none".
+	Nullable string `yaml:"nullable"`
+	// BigNumbers is the type of int64, decimal and currency columns, which may
+	// lose precision as a number: "number", the default, "bigint" or "string".
+	BigNumbers string `yaml:"big-numbers"`
+}
+
+func (o TypeScriptOptions) validate() error {
+	switch o.Nullable {
+	case "", TypeScriptNullableUnion, TypeScriptNullableOptional, TypeScriptNullableNone:
+	default:
+		return fmt.Errorf("invalid typescript nullable option: %s", o.Nullable)
+	}
+	switch o.BigNumbers {
+	case "", TypeScriptNumber, TypeScriptBigInt, TypeScriptString:
+	default:
+		return fmt.Errorf("invalid typescript big-numbers option: %s", o.BigNumbers)
+	}
+	return nil
+}
+
+func (o TypeScriptOptions) merge(other TypeScriptOptions) TypeScriptOptions {
+	if other.Nullable != "" {
+		o.Nullable = other.Nullable
+	}
+	if other.BigNumbers != "" {
+		o.BigNumbers = other.BigNumbers
+	}
+	return o
+}
+
+func typescriptBaseType(s *ColumnSchema) string {
+	t, ok := typescriptTypeMap[s.DataType]
+	if !ok {
+		t = "any"
+	}
+
+	switch s.DataType {
+	case DataTypeInt64, DataTypeDecimal, DataTypeCurrency:
+		if typeOptions.TypeScript.BigNumbers != "" {
+			t = typeOptions.TypeScript.BigNumbers
+		}
+	}
+	return t
+}
+
+func typescriptNullable(t string) string {
+	switch typeOptions.TypeScript.Nullable {
+	case "", TypeScriptNullableUnion:
+		return t + " | null"
+	default:
+		return t
+	}
+}
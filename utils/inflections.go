@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// inflectionRule replaces the suffix matched by the pattern of a lower case
+// word, the first matching rule of a list is applied.
+type inflectionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func newInflectionRules(rules [][2]string) []inflectionRule {
+	result := make([]inflectionRule, len(rules))
+	for i, rule := range rules {
+		result[i] = inflectionRule{
+			pattern:     regexp.MustCompile(rule[0]),
+			replacement: rule[1],
+		}
+	}
+	return result
+}
+
+func applyInflectionRules(rules []inflectionRule, word string) string {
+	for _, rule := range rules {
+		if rule.pattern.MatchString(word) {
+			return rule.pattern.ReplaceAllString(word, rule.replacement)
+		}
+	}
+	return word
+}
+
+// defaultIrregulars are the irregular singular and plural forms.
+var defaultIrregulars = map[string]string{
+	"child":      "children",
+	"person":     "people",
+	"man":        "men",
+	"woman":      "women",
+	"mouse":      "mice",
+	"goose":      "geese",
+	"tooth":      "teeth",
+	"foot":       "feet",
+	"ox":         "oxen",
+	"leaf":       "leaves",
+	"loaf":       "loaves",
+	"thief":      "thieves",
+	"cactus":     "cacti",
+	"focus":      "foci",
+	"fungus":     "fungi",
+	"nucleus":    "nuclei",
+	"radius":     "radii",
+	"stimulus":   "stimuli",
+	"alumnus":    "alumni",
+	"basis":      "bases",
+	"criterion":  "criteria",
+	"phenomenon": "phenomena",
+	"matrix":     "matrices",
+	"vertex":     "vertices",
+	"appendix":   "appendices",
+	"movie":      "movies",
+	"cookie":     "cookies",
+	"zombie":     "zombies",
+	"cafe":       "cafes",
+	"safe":       "safes",
+	"gulf":       "gulfs",
+	"menu":       "menus",
+	"guru":       "gurus",
+}
+
+// defaultUncountables are the words whose plural is the singular.
+var defaultUncountables = []string{
+	"data", "metadata", "info", "information", "news", "media", "equipment", "feedback",
+	"software", "hardware", "firmware", "middleware", "money", "rice", "series", "species",
+	"fish", "sheep", "deer", "moose", "aircraft", "police", "staff", "traffic", "golf",
+	"advice", "knowledge", "evidence", "furniture", "luggage", "baggage", "homework",
+}
+
+var (
+	pluralIrregulars   map[string]string
+	singularIrregulars map[string]string
+	uncountables       map[string]bool
+)
+
+func init() {
+	SetInflections(nil, nil)
+}
+
+// SetInflections sets irregular singular to plural forms and uncountable words
+// in addition to the default ones. It must not be called while words are
+// inflected.
+func SetInflections(irregulars map[string]string, uncountableWords []string) {
+	pluralIrregulars = map[string]string{}
+	singularIrregulars = map[string]string{}
+	uncountables = map[string]bool{}
+
+	add := func(singular, plural string) {
+		singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+		pluralIrregulars[singular] = plural
+		singularIrregulars[plural] = singular
+	}
+	for singular, plural := range defaultIrregulars {
+		add(singular, plural)
+	}
+	for singular, plural := range irregulars {
+		add(singular, plural)
+	}
+
+	for _, word := range defaultUncountables {
+		uncountables[word] = true
+	}
+	for _, word := range uncountableWords {
+		uncountables[strings.ToLower(word)] = true
+	}
+}
+
+// inflect applies the irregular forms, the uncountable words and the rules to
+// the last word of the word, as "Status" of "UserStatus", keeping its case.
+// Words already inflected are returned unchanged: the irregular forms of
+// inflected and the words changed by the inverse rules.
+func inflect(word string, irregulars map[string]string, inflected map[string]string, rules, inverse []inflectionRule) string {
+	if word == "" {
+		return word
+	}
+
+	i := lastWordIndex(word)
+	prefix, last := word[:i], word[i:]
+
+	lower := strings.ToLower(last)
+	var result string
+	if uncountables[lower] {
+		return word
+	} else if irregular, ok := irregulars[lower]; ok {
+		result = irregular
+	} else if _, ok := inflected[lower]; ok {
+		// Already inflected, as "people" for ToPlural.
+		return word
+	} else if inverse != nil && applyInflectionRules(inverse, lower) != lower {
+		// Already inflected, as "photos" for ToPlural.
+		return word
+	} else {
+		result = applyInflectionRules(rules, lower)
+	}
+	if result == "" {
+		// A word is never inflected to nothing, as "s" by the singular rules.
+		return word
+	}
+
+	return prefix + matchCase(last, result)
+}
+
+// lastWordIndex returns the byte index of the last word of a word made of
+// several words, which start after a separator or at an upper case letter. An
+// upper case run followed by a lone "s", as in "URLs", is a single word.
+func lastWordIndex(word string) int {
+	runes := []rune(word)
+	for i := len(runes) - 1; i > 0; i-- {
+		prev, r := runes[i-1], runes[i]
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+			return len(string(runes[:i]))
+		}
+		if !unicode.IsUpper(r) {
+			continue
+		}
+		if !unicode.IsUpper(prev) {
+			return len(string(runes[:i]))
+		}
+		if i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !(i+2 == len(runes) && runes[i+1] == 's') {
+			return len(string(runes[:i]))
+		}
+	}
+	return 0
+}
+
+// matchCase returns the inflected lower case word with the case of the
+// original word. The stem shared with the original keeps its case, the new
+// suffix is upper case if the original is all upper case.
+func matchCase(original, word string) string {
+	runes, inflected := []rune(original), []rune(word)
+	n := 0
+	for n < len(runes) && n < len(inflected) && unicode.ToLower(runes[n]) == inflected[n] {
+		n++
+	}
+
+	suffix := string(inflected[n:])
+	if strings.ToUpper(original) == original && strings.ToLower(original) != original {
+		suffix = strings.ToUpper(suffix)
+	} else if n == 0 && suffix != "" && unicode.IsUpper(runes[0]) {
+		suffix = firstRuneToUpper(suffix)
+	}
+	return string(runes[:n]) + suffix
+}
@@ -0,0 +1,137 @@
+package utils
+
+import "testing"
+
+func TestInflections(t *testing.T) {
+	tests := []struct {
+		singular string
+		plural   string
+	}{
+		{"category", "categories"},
+		{"status", "statuses"},
+		{"knife", "knives"},
+		{"photo", "photos"},
+		{"cache", "caches"},
+		{"box", "boxes"},
+		{"person", "people"},
+		{"criterion", "criteria"},
+		{"data", "data"},
+		{"info", "info"},
+		{"news", "news"},
+		{"Category", "Categories"},
+		{"USER", "USERS"},
+		{"UserStatus", "UserStatuses"},
+		{"user_status", "user_statuses"},
+		{"OrderItem", "OrderItems"},
+		{"UserData", "UserData"},
+	}
+
+	for _, tt := range tests {
+		if got := ToPlural(tt.singular); got != tt.plural {
+			t.Errorf("ToPlural(%q) = %q, want %q", tt.singular, got, tt.plural)
+		}
+		if got := ToSingular(tt.plural); got != tt.singular {
+			t.Errorf("ToSingular(%q) = %q, want %q", tt.plural, got, tt.singular)
+		}
+		if got := ToPlural(tt.plural); got != tt.plural {
+			t.Errorf("ToPlural(%q) = %q, want %q", tt.plural, got, tt.plural)
+		}
+		if got := ToSingular(tt.singular); got != tt.singular {
+			t.Errorf("ToSingular(%q) = %q, want %q", tt.singular, got, tt.singular)
+		}
+	}
+}
+
+func TestToPlural(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"photos", "photos"},
+		{"statuses", "statuses"},
+		{"caches", "caches"},
+		{"indices", "indices"},
+		{"UserStatuses", "UserStatuses"},
+		{"index", "indexes"},
+		{"alias", "aliases"},
+		{"bus", "buses"},
+	}
+
+	for _, tt := range tests {
+		if got := ToPlural(tt.word); got != tt.want {
+			t.Errorf("ToPlural(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestToSingular(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"indices", "index"},
+		{"indexes", "index"},
+		{"matrices", "matrix"},
+		{"statuses", "status"},
+		{"aliases", "alias"},
+		{"status", "status"},
+		{"addresses", "address"},
+	}
+
+	for _, tt := range tests {
+		if got := ToSingular(tt.word); got != tt.want {
+			t.Errorf("ToSingular(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSetInflections(t *testing.T) {
+	SetInflections(map[string]string{"sku": "skus"}, []string{"Sheep", "kudos"})
+	defer SetInflections(nil, nil)
+
+	tests := []struct {
+		fn   func(string) string
+		word string
+		want string
+	}{
+		{ToPlural, "sku", "skus"},
+		{ToPlural, "skus", "skus"},
+		{ToSingular, "skus", "sku"},
+		{ToPlural, "ProductSku", "ProductSkus"},
+		{ToSingular, "ProductSkus", "ProductSku"},
+		{ToPlural, "kudos", "kudos"},
+		{ToSingular, "kudos", "kudos"},
+		{ToPlural, "sheep", "sheep"},
+		{ToPlural, "person", "people"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.word); got != tt.want {
+			t.Errorf("%q inflected to %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestInflectShortWords(t *testing.T) {
+	tests := []struct {
+		fn   func(string) string
+		word string
+		want string
+	}{
+		{ToSingular, "s", "s"},
+		{ToSingular, "S", "S"},
+		{ToSingular, "", ""},
+		{ToPlural, "", ""},
+		{ToSingularPascalCase, "s", "S"},
+		{ToSingularPascalCase, "a_s", "AS"},
+		{ToSingularCamelCase, "a_s", "aS"},
+		{ToSingularTitleCase, "s", "S"},
+		{ToPluralPascalCase, "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.fn(tt.word); got != tt.want {
+			t.Errorf("%q inflected to %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultInitialisms are the initialisms of golint.
+var DefaultInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID",
+	"IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP",
+	"TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP",
+	"XSRF", "XSS",
+}
+
+var initialisms = toInitialismSet(DefaultInitialisms)
+
+// SetInitialisms sets the initialisms of the initialism-aware naming
+// functions, nil restores the default initialisms. It must not be called
+// while names are converted.
+func SetInitialisms(words []string) {
+	if words == nil {
+		words = DefaultInitialisms
+	}
+	initialisms = toInitialismSet(words)
+}
+
+func toInitialismSet(words []string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range words {
+		set[strings.ToUpper(word)] = true
+	}
+	return set
+}
+
+// IsInitialism reports whether the word is an initialism, ignoring case.
+func IsInitialism(word string) bool {
+	return initialisms[strings.ToUpper(word)]
+}
+
+// SplitWordsInitialisms splits the value into words like SplitWords, but keeps
+// runs of upper case letters and digits within words, so that APIKey is split
+// into API and Key and utf8Value into utf8 and Value.
+func SplitWordsInitialisms(value string, lower bool) []string {
+	result := []string{}
+
+	for _, part := range reNotLetterOrDigit.Split(value, -1) {
+		for _, word := range splitCaseWords(part) {
+			if lower {
+				word = strings.ToLower(word)
+			}
+			result = append(result, word)
+		}
+	}
+
+	return result
+}
+
+// splitCaseWords splits before an upper case letter following a lower case
+// letter or a digit, and before the last upper case letter of a run followed
+// by a lower case letter. A run followed by a lone "s" is kept as one word, as
+// the plural "URLs".
+func splitCaseWords(s string) []string {
+	runes := []rune(s)
+	words := []string{}
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		split := false
+		if unicode.IsUpper(cur) {
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				split = true
+			} else if unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				split = !pluralSuffixAt(runes, i+1)
+			}
+		}
+		if split {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// pluralSuffixAt reports whether runes[i] is a lone "s" ending a word.
+func pluralSuffixAt(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// initialismWord returns the word in upper case if it is an initialism, an
+// initialism in upper case followed by "s" if it is the plural of one, as
+// "IDs", and with an upper case first letter otherwise.
+func initialismWord(word string) string {
+	if IsInitialism(word) {
+		return strings.ToUpper(word)
+	}
+	if stem, ok := strings.CutSuffix(strings.ToLower(word), "s"); ok && IsInitialism(stem) {
+		return strings.ToUpper(stem) + "s"
+	}
+	return firstRuneToUpper(strings.ToLower(word))
+}
+
+func ToCamelCaseInitialisms(value string) string {
+	words := SplitWordsInitialisms(value, false)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+			continue
+		}
+		words[i] = initialismWord(word)
+	}
+	return strings.Join(words, "")
+}
+
+func ToPascalCaseInitialisms(value string) string {
+	words := SplitWordsInitialisms(value, false)
+	for i, word := range words {
+		words[i] = initialismWord(word)
+	}
+	return strings.Join(words, "")
+}
+
+func ToSnakeCaseInitialisms(value string) string {
+	return strings.Join(SplitWordsInitialisms(value, true), "_")
+}
+
+func ToKebabCaseInitialisms(value string) string {
+	return strings.Join(SplitWordsInitialisms(value, true), "-")
+}
@@ -55,7 +55,42 @@ func ToPascalCase(value string) string {
 	return strings.Join(words, "")
 }
 
+func ToScreamingSnakeCase(value string) string {
+	words := SplitWords(value, true)
+	return strings.ToUpper(strings.Join(words, "_"))
+}
+
+func ToDotCase(value string) string {
+	words := SplitWords(value, true)
+	return strings.Join(words, ".")
+}
+
+func ToTitleCase(value string) string {
+	words := SplitWords(value, true)
+	return strings.Join(titleWords(words), " ")
+}
+
+func ToPathCase(value string) string {
+	words := SplitWords(value, true)
+	return strings.Join(words, "/")
+}
+
+func ToFlatCase(value string) string {
+	words := SplitWords(value, true)
+	return strings.Join(words, "")
+}
+
+func titleWords(words []string) []string {
+	for i, word := range words {
+		words[i] = firstRuneToUpper(word)
+	}
+	return words
+}
+
 func firstRuneToUpper(s string) string {
+	if s == "" {
+		return s
+	}
 	runes := []rune(s)
 	return strings.ToUpper(string(runes[0])) + string(runes[1:])
 }
@@ -5,23 +5,17 @@ import (
 )
 
 func ToPluralSnakeCase(value string) string {
-	words := SplitWords(value, true)
-	i := len(words) - 1
-	words[i] = ToPlural(words[i])
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
 	return strings.Join(words, "_")
 }
 
 func ToPluralKebabCase(value string) string {
-	words := SplitWords(value, true)
-	i := len(words) - 1
-	words[i] = ToPlural(words[i])
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
 	return strings.Join(words, "-")
 }
 
 func ToPluralCamelCase(value string) string {
-	words := SplitWords(value, true)
-	i := len(words) - 1
-	words[i] = ToPlural(words[i])
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
 	for i, word := range words {
 		if i == 0 {
 			continue
@@ -32,11 +26,97 @@ func ToPluralCamelCase(value string) string {
 }
 
 func ToPluralPascalCase(value string) string {
-	words := SplitWords(value, true)
-	i := len(words) - 1
-	words[i] = ToPlural(words[i])
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
 	for i, word := range words {
 		words[i] = firstRuneToUpper(word)
 	}
 	return strings.Join(words, "")
 }
+
+func ToPluralScreamingSnakeCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
+	return strings.ToUpper(strings.Join(words, "_"))
+}
+
+func ToPluralDotCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
+	return strings.Join(words, ".")
+}
+
+func ToPluralTitleCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
+	return strings.Join(titleWords(words), " ")
+}
+
+func ToPluralPathCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
+	return strings.Join(words, "/")
+}
+
+func ToPluralFlatCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToPlural)
+	return strings.Join(words, "")
+}
+
+func ToSingularScreamingSnakeCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.ToUpper(strings.Join(words, "_"))
+}
+
+func ToSingularDotCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.Join(words, ".")
+}
+
+func ToSingularTitleCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.Join(titleWords(words), " ")
+}
+
+func ToSingularPathCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.Join(words, "/")
+}
+
+func ToSingularFlatCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.Join(words, "")
+}
+
+func ToSingularSnakeCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.Join(words, "_")
+}
+
+func ToSingularKebabCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	return strings.Join(words, "-")
+}
+
+func ToSingularCamelCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	for i, word := range words {
+		if i == 0 {
+			continue
+		}
+		words[i] = firstRuneToUpper(word)
+	}
+	return strings.Join(words, "")
+}
+
+func ToSingularPascalCase(value string) string {
+	words := inflectLastWord(SplitWords(value, true), ToSingular)
+	for i, word := range words {
+		words[i] = firstRuneToUpper(word)
+	}
+	return strings.Join(words, "")
+}
+
+// inflectLastWord replaces the last word by its plural or singular form.
+func inflectLastWord(words []string, inflect func(string) string) []string {
+	if len(words) > 0 {
+		i := len(words) - 1
+		words[i] = inflect(words[i])
+	}
+	return words
+}
@@ -1,49 +1,21 @@
 package utils
 
-import (
-	"strings"
-)
-
-var irregularPluralMap = map[string]string{
-	"child":     "children",
-	"person":    "people",
-	"man":       "men",
-	"woman":     "women",
-	"mouse":     "mice",
-	"goose":     "geese",
-	"tooth":     "teeth",
-	"foot":      "feet",
-	"knife":     "knives",
-	"leaf":      "leaves",
-	"wolf":      "wolves",
-	"shelf":     "shelves",
-	"loaf":      "loaves",
-	"cactus":    "cacti",
-	"focus":     "foci",
-	"fungus":    "fungi",
-	"nucleus":   "nuclei",
-	"radius":    "radii",
-	"basis":     "bases",
-	"analysis":  "analyses",
-	"diagnosis": "diagnoses",
-	"thesis":    "theses",
-	"crisis":    "crises",
-}
-
-var pluralSuffixForEs = []string{"s", "x", "z", "sh", "ch"}
+var pluralRules = newInflectionRules([][2]string{
+	{`(quiz)$`, `${1}zes`},
+	{`^(kn|l|w|midw|housew|penkn)ife$`, `${1}ives`},
+	{`([lr])f$`, `${1}ves`},
+	{`(x|ch|ss|sh|zz)$`, `${1}es`},
+	{`([^aeiouy]|qu)y$`, `${1}ies`},
+	{`(buffal|tomat|potat|her|ech|vet|torped)o$`, `${1}oes`},
+	{`(analy|diagno|parenthe|progno|synop|the|cri|bas|oa)sis$`, `${1}ses`},
+	{`^(ax|test)is$`, `${1}es`},
+	{`(us|as|is|os)$`, `${1}es`},
+	{`z$`, `zes`},
+	{`$`, `s`},
+})
 
+// ToPlural returns the plural form of a word, keeping its case. Words the
+// singular rules take as plural are returned unchanged.
 func ToPlural(word string) string {
-	word = strings.ToLower(word)
-	plural, ok := irregularPluralMap[word]
-	if ok {
-		return plural
-	}
-
-	for _, suffix := range pluralSuffixForEs {
-		if strings.HasSuffix(word, suffix) {
-			return word + "es"
-		}
-	}
-
-	return word + "s"
+	return inflect(word, pluralIrregulars, singularIrregulars, pluralRules, singularRules)
 }
@@ -1,51 +1,26 @@
 package utils
 
-import (
-	"strings"
-)
-
-var irregularSingularMap = map[string]string{
-	"children":  "child",
-	"people":    "person",
-	"men":       "man",
-	"women":     "woman",
-	"mice":      "mouse",
-	"geese":     "goose",
-	"teeth":     "tooth",
-	"feet":      "foot",
-	"shoes":     "shoe",
-	"knives":    "knife",
-	"leaves":    "leaf",
-	"wolves":    "wolf",
-	"shelves":   "shelf",
-	"loaves":    "loaf",
-	"cacti":     "cactus",
-	"foci":      "focus",
-	"fungi":     "fungus",
-	"nuclei":    "nucleus",
-	"radii":     "radius",
-	"bases":     "basis",
-	"analyses":  "analysis",
-	"diagnoses": "diagnosis",
-	"theses":    "thesis",
-	"crises":    "crisis",
-}
+var singularRules = newInflectionRules([][2]string{
+	{`(quiz)zes$`, `${1}`},
+	{`^(kn|l|w|midw|housew|penkn)ives$`, `${1}ife`},
+	{`^(wo|she|ha|ca|se|e)lves$`, `${1}lf`},
+	{`^(dwa|sca|wha)rves$`, `${1}rf`},
+	{`^(ax|test)es$`, `${1}is`},
+	{`(^a|cac|headac|moustac|mustac|nic|avalanc)hes$`, `${1}he`},
+	{`(matr|append)ices$`, `${1}ix`},
+	{`(vert|ind)ices$`, `${1}ex`},
+	{`(x|ch|ss|sh|zz)es$`, `${1}`},
+	{`([^aeiouy]|qu)ies$`, `${1}y`},
+	{`(buffal|tomat|potat|her|ech|vet|torped)oes$`, `${1}o`},
+	{`(analy|diagno|parenthe|progno|synop|the|cri|oa)ses$`, `${1}sis`},
+	{`(status|bonus|campus|bus|virus|census|circus|surplus|syllabus|prospectus|nexus|plus|minus|corpus|sinus|alias|canvas|atlas|gas|bias)es$`, `${1}`},
+	// Words ending in -us are taken as singular, such as status, plurals of
+	// words ending in -u need an irregular form.
+	{`(ss|us|is|alias|canvas|atlas|gas|bias)$`, `${1}`},
+	{`s$`, ``},
+})
 
+// ToSingular returns the singular form of a word, keeping its case.
 func ToSingular(word string) string {
-	if singular, ok := irregularSingularMap[word]; ok {
-		return singular
-	}
-
-	if strings.HasSuffix(word, "es") && len(word) > 2 {
-		c := word[len(word)-3]
-		if c != 's' && c != 'x' && c != 'z' && c != 'h' {
-			return word[:len(word)-2]
-		}
-	}
-
-	if strings.HasSuffix(word, "s") {
-		return word[:len(word)-1]
-	}
-
-	return word
+	return inflect(word, singularIrregulars, pluralIrregulars, singularRules, nil)
 }